@@ -17,16 +17,16 @@ limitations under the License.
 package deploy
 
 import (
-	v1alpha1deploy "github.com/tbd-paas/platform-config-operator/apis/deploy/v1alpha1"
-	v1alpha1platformconfig "github.com/tbd-paas/platform-config-operator/apis/deploy/v1alpha1/platformconfig"
+	v1beta1deploy "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+	v1beta1platformconfig "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1/platformconfig"
 )
 
 // Code generated by operator-builder. DO NOT EDIT.
 
 // PlatformConfigLatestGroupVersion returns the latest group version object associated with this
 // particular kind.
-var PlatformConfigLatestGroupVersion = v1alpha1deploy.GroupVersion
+var PlatformConfigLatestGroupVersion = v1beta1deploy.GroupVersion
 
 // PlatformConfigLatestSample returns the latest sample manifest associated with this
 // particular kind.
-var PlatformConfigLatestSample = v1alpha1platformconfig.Sample(false)
+var PlatformConfigLatestSample = v1beta1platformconfig.Sample(false)
@@ -18,6 +18,7 @@ package deploy
 
 import (
 	v1alpha1deploy "github.com/tbd-paas/platform-config-operator/apis/deploy/v1alpha1"
+	v1beta1deploy "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
 	// +kubebuilder:scaffold:operator-builder:imports
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -27,6 +28,7 @@ import (
 func PlatformOperatorsGroupVersions() []schema.GroupVersion {
 	return []schema.GroupVersion{
 		v1alpha1deploy.GroupVersion,
+		v1beta1deploy.GroupVersion,
 		// +kubebuilder:scaffold:operator-builder:groupversions
 	}
 }
@@ -17,16 +17,16 @@ limitations under the License.
 package deploy
 
 import (
-	v1alpha1deploy "github.com/tbd-paas/platform-config-operator/apis/deploy/v1alpha1"
-	v1alpha1platformoperators "github.com/tbd-paas/platform-config-operator/apis/deploy/v1alpha1/platformoperators"
+	v1beta1deploy "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+	v1beta1platformoperators "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1/platformoperators"
 )
 
 // Code generated by operator-builder. DO NOT EDIT.
 
 // PlatformOperatorsLatestGroupVersion returns the latest group version object associated with this
 // particular kind.
-var PlatformOperatorsLatestGroupVersion = v1alpha1deploy.GroupVersion
+var PlatformOperatorsLatestGroupVersion = v1beta1deploy.GroupVersion
 
 // PlatformOperatorsLatestSample returns the latest sample manifest associated with this
 // particular kind.
-var PlatformOperatorsLatestSample = v1alpha1platformoperators.Sample(false)
+var PlatformOperatorsLatestSample = v1beta1platformoperators.Sample(false)
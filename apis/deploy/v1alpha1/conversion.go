@@ -0,0 +1,81 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ConvertedDataAnnotation is the annotation which stores the fields of the hub version of an
+// object on an object of this version.  Fields which were introduced after this version are not
+// represented here, so storing them allows a conversion back to the hub version to be lossless.
+const ConvertedDataAnnotation = "deploy.platform.tbd.io/converted-data"
+
+// marshalConvertedData stores the spec and status of the hub object on the annotations of the
+// converted object.
+func marshalConvertedData(hub runtime.Object, converted metav1.Object) error {
+	data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(hub)
+	if err != nil {
+		return fmt.Errorf("unable to convert hub object to unstructured, %w", err)
+	}
+
+	delete(data, "metadata")
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("unable to marshal converted data, %w", err)
+	}
+
+	annotations := converted.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	annotations[ConvertedDataAnnotation] = string(raw)
+	converted.SetAnnotations(annotations)
+
+	return nil
+}
+
+// unmarshalConvertedData restores the spec and status of a hub object which were previously
+// stored on the annotations of the converted object, and removes the annotation from the
+// restored hub object.
+func unmarshalConvertedData(converted metav1.Object, hub metav1.Object) error {
+	raw, ok := converted.GetAnnotations()[ConvertedDataAnnotation]
+	if !ok {
+		return nil
+	}
+
+	if err := json.Unmarshal([]byte(raw), hub); err != nil {
+		return fmt.Errorf("unable to unmarshal converted data, %w", err)
+	}
+
+	annotations := hub.GetAnnotations()
+	delete(annotations, ConvertedDataAnnotation)
+
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+
+	hub.SetAnnotations(annotations)
+
+	return nil
+}
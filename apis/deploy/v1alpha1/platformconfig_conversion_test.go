@@ -0,0 +1,189 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	fuzz "github.com/google/gofuzz"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+)
+
+func TestPlatformConfigConversionRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	original := &PlatformConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "config",
+			Labels:      map[string]string{"app": "test"},
+			Annotations: map[string]string{"note": "test"},
+		},
+		Spec: PlatformConfigSpec{
+			Platform: PlatformConfigSpecPlatform{
+				Certificates: PlatformConfigSpecPlatformCertificates{
+					Namespace:      "certs",
+					DeploymentSize: "medium",
+				},
+				Identity: PlatformConfigSpecPlatformIdentity{
+					Namespace:      "identity",
+					DeploymentSize: "large",
+				},
+			},
+			Cloud: PlatformConfigSpecCloud{
+				Type:  "aws",
+				Local: false,
+			},
+		},
+		Status: PlatformConfigStatus{
+			Created:               true,
+			DependenciesSatisfied: true,
+		},
+	}
+
+	hub := &deployv1beta1.PlatformConfig{}
+	require.NoError(t, original.DeepCopy().ConvertTo(hub))
+
+	require.Equal(t, "certs", hub.Spec.Certificates.Namespace)
+	require.Equal(t, "medium", hub.Spec.Certificates.DeploymentSize)
+	require.Equal(t, "identity", hub.Spec.Identity.Namespace)
+	require.Equal(t, "large", hub.Spec.Identity.DeploymentSize)
+	require.Equal(t, "aws", hub.Spec.Cloud.Type)
+	require.False(t, hub.Spec.Cloud.Local)
+	require.True(t, hub.Spec.Certificates.Enabled)
+	require.True(t, hub.Spec.Identity.Enabled)
+	require.True(t, hub.Status.Created)
+
+	converted := &PlatformConfig{}
+	require.NoError(t, converted.ConvertFrom(hub))
+	require.Contains(t, converted.Annotations, ConvertedDataAnnotation)
+
+	delete(converted.Annotations, ConvertedDataAnnotation)
+	require.Equal(t, original, converted)
+}
+
+func TestPlatformConfigHubConversionRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	original := &deployv1beta1.PlatformConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "config",
+			Labels: map[string]string{"app": "test"},
+		},
+		Spec: deployv1beta1.PlatformConfigSpec{
+			Certificates: deployv1beta1.PlatformConfigSpecCertificates{
+				Namespace:      "certs",
+				DeploymentSize: "small",
+			},
+			Identity: deployv1beta1.PlatformConfigSpecIdentity{
+				Namespace:      "identity",
+				DeploymentSize: "medium",
+			},
+			Cloud: deployv1beta1.PlatformConfigSpecCloud{
+				Type:  "aws",
+				Local: true,
+			},
+		},
+	}
+
+	spoke := &PlatformConfig{}
+	require.NoError(t, spoke.ConvertFrom(original.DeepCopy()))
+
+	hub := &deployv1beta1.PlatformConfig{}
+	require.NoError(t, spoke.ConvertTo(hub))
+	require.NotContains(t, hub.Annotations, ConvertedDataAnnotation)
+	require.Equal(t, original, hub)
+}
+
+func TestPlatformConfigConversionSpokeTakesPrecedence(t *testing.T) {
+	t.Parallel()
+
+	spoke := &PlatformConfig{}
+	require.NoError(t, spoke.ConvertFrom(&deployv1beta1.PlatformConfig{
+		Spec: deployv1beta1.PlatformConfigSpec{
+			Certificates: deployv1beta1.PlatformConfigSpecCertificates{Namespace: "before"},
+		},
+	}))
+
+	// changes made via the older version must not be overwritten by the preserved hub data
+	spoke.Spec.Platform.Certificates.Namespace = "after"
+
+	hub := &deployv1beta1.PlatformConfig{}
+	require.NoError(t, spoke.ConvertTo(hub))
+	require.Equal(t, "after", hub.Spec.Certificates.Namespace)
+}
+
+func TestPlatformConfigConversionDefaults(t *testing.T) {
+	t.Parallel()
+
+	hub := &deployv1beta1.PlatformConfig{}
+	require.NoError(t, (&PlatformConfig{}).ConvertTo(hub))
+
+	// fields which were introduced after this version are defaulted from the schema of the hub
+	require.True(t, hub.Spec.Certificates.Enabled)
+	require.True(t, hub.Spec.Identity.Enabled)
+	require.Equal(t, "restricted", hub.Spec.Certificates.PodSecurity.Enforce)
+	require.Equal(t, "restricted", hub.Spec.Identity.PodSecurity.Warn)
+	require.Equal(t, "default", hub.Spec.NetworkPolicy.Mode)
+	require.Equal(t, "ECDSA", hub.Spec.Certificates.RootCA.KeyAlgorithm)
+	require.Equal(t, 256, hub.Spec.Certificates.RootCA.KeySize)
+	require.Equal(t, 87600*time.Hour, hub.Spec.Certificates.RootCA.Validity.Duration)
+	require.Equal(t, 720*time.Hour, hub.Spec.Certificates.RootCA.RenewBefore.Duration)
+	require.Equal(t, "tbd platform root CA", hub.Spec.Certificates.RootCA.Subject.CommonName)
+	require.Equal(t,
+		map[string]string{"certificates.platform.tbd.io/inject-ca-bundle": "true"},
+		hub.Spec.Certificates.Trust.Injection.NamespaceSelector,
+	)
+	require.True(t, hub.Spec.Identity.ServiceAccountIssuerDiscovery.Enabled)
+	require.Equal(t, "service-account-issuer-discovery", hub.Spec.Identity.ServiceAccountIssuerDiscovery.ConfigMapName)
+	require.Empty(t, hub.Spec.Certificates.Issuers)
+	require.Empty(t, hub.Spec.Identity.RoleBindings)
+	require.Empty(t, hub.Spec.Overrides)
+}
+
+func TestPlatformConfigHubConversionLossless(t *testing.T) {
+	t.Parallel()
+
+	fuzzer := newConversionFuzzer()
+
+	for i := 0; i < 50; i++ {
+		original := &deployv1beta1.PlatformConfig{ObjectMeta: metav1.ObjectMeta{Name: "config"}}
+		fuzzer.Fuzz(&original.Spec)
+		fuzzer.Fuzz(&original.Status)
+
+		spoke := &PlatformConfig{}
+		require.NoError(t, spoke.ConvertFrom(original.DeepCopy()))
+
+		hub := &deployv1beta1.PlatformConfig{}
+		require.NoError(t, spoke.ConvertTo(hub))
+		require.Equal(t, original, hub)
+	}
+}
+
+// newConversionFuzzer returns a fuzzer which fills every field of the hub version, so that
+// round trip tests cover fields as they are added to the hub version.
+func newConversionFuzzer() *fuzz.Fuzzer {
+	return fuzz.NewWithSeed(1).NilChance(0.2).Funcs(
+		// times are serialized with a precision of seconds
+		func(in *metav1.Time, c fuzz.Continue) {
+			*in = metav1.Unix(c.Int63n(1<<32), 0)
+		},
+	)
+}
@@ -102,7 +102,7 @@ type PlatformConfigSpecCloud struct {
 	// (Default: true)
 	//
 	//	Whether this cloud is deployed as a local cloud to use for testing scenarios.
-	Local bool `json:"local,omitempty"`
+	Local bool `json:"local"`
 }
 
 // PlatformConfigStatus defines the observed state of PlatformConfig.
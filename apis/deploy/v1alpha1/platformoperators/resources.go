@@ -25,6 +25,8 @@ import (
 	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
 
 	deployv1alpha1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1alpha1"
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+	v1beta1platformoperators "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1/platformoperators"
 )
 
 // samplePlatformOperators is a sample containing all fields
@@ -54,25 +56,19 @@ func Sample(requiredOnly bool) string {
 }
 
 // Generate returns the child resources that are associated with this workload given
-// appropriate structured inputs.
+// appropriate structured inputs.  The workload is converted to the hub version, which owns the
+// logic for generating child resources.
 func Generate(
 	workloadObj deployv1alpha1.PlatformOperators,
 	reconciler workload.Reconciler,
 	req *workload.Request,
 ) ([]client.Object, error) {
-	resourceObjects := []client.Object{}
-
-	for _, f := range CreateFuncs {
-		resources, err := f(&workloadObj, reconciler, req)
-
-		if err != nil {
-			return nil, err
-		}
-
-		resourceObjects = append(resourceObjects, resources...)
+	var hub deployv1beta1.PlatformOperators
+	if err := workloadObj.ConvertTo(&hub); err != nil {
+		return nil, fmt.Errorf("unable to convert workload to hub version, %w", err)
 	}
 
-	return resourceObjects, nil
+	return v1beta1platformoperators.Generate(hub, reconciler, req)
 }
 
 // GenerateForCLI returns the child resources that are associated with this workload given
@@ -90,55 +86,6 @@ func GenerateForCLI(workloadFile []byte) ([]client.Object, error) {
 	return Generate(workloadObj, nil, nil)
 }
 
-// CreateFuncs is an array of functions that are called to create the child resources for the controller
-// in memory during the reconciliation loop prior to persisting the changes or updates to the Kubernetes
-// database.
-var CreateFuncs = []func(
-	*deployv1alpha1.PlatformOperators,
-	workload.Reconciler,
-	*workload.Request,
-) ([]client.Object, error){
-	CreateCRDCertmanagersCertificatesPlatformTbdIo,
-	CreateCRDTrustmanagersCertificatesPlatformTbdIo,
-	CreateServiceAccountNamespaceCertificatesOperatorControllerManager,
-	CreateRoleNamespaceCertificatesOperatorLeaderElectionRole,
-	CreateClusterRoleCertificatesOperatorCertificatesCertmanagerEditorRole,
-	CreateClusterRoleCertificatesOperatorCertificatesCertmanagerViewerRole,
-	CreateClusterRoleCertificatesOperatorCertificatesTrustmanagerEditorRole,
-	CreateClusterRoleCertificatesOperatorCertificatesTrustmanagerViewerRole,
-	CreateClusterRoleCertificatesOperatorManagerRole,
-	CreateRoleBindingNamespaceCertificatesOperatorLeaderElectionRolebinding,
-	CreateClusterRoleBindingCertificatesOperatorManagerRolebinding,
-	CreateDeploymentNamespaceCertificatesOperatorControllerManager,
-	CreateCRDAwspodidentitywebhooksIdentityPlatformTbdIo,
-	CreateServiceAccountNamespaceIdentityOperatorControllerManager,
-	CreateRoleNamespaceIdentityOperatorLeaderElectionRole,
-	CreateClusterRoleIdentityOperatorIdentityAwspodidentitywebhookEditorRole,
-	CreateClusterRoleIdentityOperatorIdentityAwspodidentitywebhookViewerRole,
-	CreateClusterRoleIdentityOperatorManagerRole,
-	CreateRoleBindingNamespaceIdentityOperatorLeaderElectionRolebinding,
-	CreateClusterRoleBindingIdentityOperatorManagerRolebinding,
-	CreateDeploymentNamespaceIdentityOperatorControllerManager,
-}
-
-// InitFuncs is an array of functions that are called prior to starting the controller manager.  This is
-// necessary in instances which the controller needs to "own" objects which depend on resources to
-// pre-exist in the cluster. A common use case for this is the need to own a custom resource.
-// If the controller needs to own a custom resource type, the CRD that defines it must
-// first exist. In this case, the InitFunc will create the CRD so that the controller
-// can own custom resources of that type.  Without the InitFunc the controller will
-// crash loop because when it tries to own a non-existent resource type during manager
-// setup, it will fail.
-var InitFuncs = []func(
-	*deployv1alpha1.PlatformOperators,
-	workload.Reconciler,
-	*workload.Request,
-) ([]client.Object, error){
-	CreateCRDCertmanagersCertificatesPlatformTbdIo,
-	CreateCRDTrustmanagersCertificatesPlatformTbdIo,
-	CreateCRDAwspodidentitywebhooksIdentityPlatformTbdIo,
-}
-
 func ConvertWorkload(component workload.Workload) (*deployv1alpha1.PlatformOperators, error) {
 	p, ok := component.(*deployv1alpha1.PlatformOperators)
 	if !ok {
@@ -0,0 +1,77 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+	"github.com/tbd-paas/platform-config-operator/internal/crdschema"
+)
+
+// ConvertTo converts this PlatformOperators to the hub version.
+func (component *PlatformOperators) ConvertTo(hubRaw conversion.Hub) error {
+	hub, ok := hubRaw.(*deployv1beta1.PlatformOperators)
+	if !ok {
+		return fmt.Errorf("%w from %T", ErrUnableToConvertPlatformOperators, hubRaw)
+	}
+
+	// fields which do not exist in this version are defaulted from the schema of the hub version as
+	// the API server would default them, unless they were previously stored on the converted data
+	// annotation
+	if _, ok := component.GetAnnotations()[ConvertedDataAnnotation]; !ok {
+		if err := crdschema.DefaultSpec(deployv1beta1.GroupVersion.WithKind("PlatformOperators"), hub); err != nil {
+			return err
+		}
+	}
+
+	hub.ObjectMeta = *component.ObjectMeta.DeepCopy()
+
+	if err := unmarshalConvertedData(component, hub); err != nil {
+		return err
+	}
+
+	hub.Spec.Namespace = component.Spec.Namespace
+
+	hub.Status.Created = component.Status.Created
+	hub.Status.DependenciesSatisfied = component.Status.DependenciesSatisfied
+	hub.Status.Conditions = component.Status.Conditions
+	hub.Status.Resources = component.Status.Resources
+
+	return nil
+}
+
+// ConvertFrom converts the hub version to this PlatformOperators.
+func (component *PlatformOperators) ConvertFrom(hubRaw conversion.Hub) error {
+	hub, ok := hubRaw.(*deployv1beta1.PlatformOperators)
+	if !ok {
+		return fmt.Errorf("%w from %T", ErrUnableToConvertPlatformOperators, hubRaw)
+	}
+
+	component.ObjectMeta = *hub.ObjectMeta.DeepCopy()
+
+	component.Spec.Namespace = hub.Spec.Namespace
+
+	component.Status.Created = hub.Status.Created
+	component.Status.DependenciesSatisfied = hub.Status.DependenciesSatisfied
+	component.Status.Conditions = hub.Status.Conditions
+	component.Status.Resources = hub.Status.Resources
+
+	return marshalConvertedData(hub, component)
+}
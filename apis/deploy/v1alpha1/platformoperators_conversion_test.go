@@ -0,0 +1,117 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+)
+
+func TestPlatformOperatorsConversionRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	original := &PlatformOperators{
+		ObjectMeta: metav1.ObjectMeta{Name: "operators"},
+		Spec:       PlatformOperatorsSpec{Namespace: "operators"},
+		Status:     PlatformOperatorsStatus{Created: true},
+	}
+
+	hub := &deployv1beta1.PlatformOperators{}
+	require.NoError(t, original.DeepCopy().ConvertTo(hub))
+	require.Equal(t, "operators", hub.Spec.Namespace)
+	require.True(t, hub.Spec.Operators.Certificates.Enabled)
+	require.True(t, hub.Spec.Operators.Identity.Enabled)
+	require.Equal(t, "v0.0.0-alpha.2", hub.Spec.Operators.Certificates.Version)
+	require.Equal(t, 1, hub.Spec.Operators.Identity.Replicas)
+
+	converted := &PlatformOperators{}
+	require.NoError(t, converted.ConvertFrom(hub))
+
+	delete(converted.Annotations, ConvertedDataAnnotation)
+	if len(converted.Annotations) == 0 {
+		converted.Annotations = nil
+	}
+
+	require.Equal(t, original, converted)
+}
+
+func TestPlatformOperatorsHubConversionRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	original := &deployv1beta1.PlatformOperators{
+		ObjectMeta: metav1.ObjectMeta{Name: "operators"},
+		Spec: deployv1beta1.PlatformOperatorsSpec{
+			Namespace: "operators",
+			Operators: deployv1beta1.PlatformOperatorsSpecOperators{
+				Certificates: deployv1beta1.PlatformOperatorsSpecOperatorsCertificates{
+					Enabled:  true,
+					Version:  "v0.0.0-alpha.2",
+					Replicas: 2,
+					LogLevel: "debug",
+				},
+				Identity: deployv1beta1.PlatformOperatorsSpecOperatorsIdentity{
+					Enabled: false,
+				},
+			},
+		},
+	}
+
+	spoke := &PlatformOperators{}
+	require.NoError(t, spoke.ConvertFrom(original.DeepCopy()))
+
+	hub := &deployv1beta1.PlatformOperators{}
+	require.NoError(t, spoke.ConvertTo(hub))
+	require.Equal(t, original, hub)
+}
+
+func TestPlatformOperatorsConversionDefaults(t *testing.T) {
+	t.Parallel()
+
+	hub := &deployv1beta1.PlatformOperators{}
+	require.NoError(t, (&PlatformOperators{}).ConvertTo(hub))
+
+	// fields which were introduced after this version are defaulted from the schema of the hub
+	require.Equal(t, "restricted", hub.Spec.PodSecurity.Enforce)
+	require.Equal(t, "default", hub.Spec.NetworkPolicy.Mode)
+	require.False(t, hub.Spec.Metrics.Certificate.Enabled)
+	require.Empty(t, hub.Spec.Metrics.Certificate.IssuerName)
+	require.Equal(t, "10m", hub.Spec.Operators.Certificates.Resources.Requests.CPU)
+	require.Equal(t, "64Mi", hub.Spec.Operators.Identity.Resources.Limits.Memory)
+}
+
+func TestPlatformOperatorsHubConversionLossless(t *testing.T) {
+	t.Parallel()
+
+	fuzzer := newConversionFuzzer()
+
+	for i := 0; i < 50; i++ {
+		original := &deployv1beta1.PlatformOperators{ObjectMeta: metav1.ObjectMeta{Name: "operators"}}
+		fuzzer.Fuzz(&original.Spec)
+		fuzzer.Fuzz(&original.Status)
+
+		spoke := &PlatformOperators{}
+		require.NoError(t, spoke.ConvertFrom(original.DeepCopy()))
+
+		hub := &deployv1beta1.PlatformOperators{}
+		require.NoError(t, spoke.ConvertTo(hub))
+		require.Equal(t, original, hub)
+	}
+}
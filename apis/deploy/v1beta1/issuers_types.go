@@ -0,0 +1,127 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+const (
+	IssuerTypeSelfSigned       = "selfSigned"
+	IssuerTypeCA               = "ca"
+	IssuerTypeACMEHTTP01       = "acmeHTTP01"
+	IssuerTypeACMEDNS01Route53 = "acmeDNS01Route53"
+)
+
+// Issuer defines a cert-manager ClusterIssuer which is created once the certificates capability
+// is ready.
+// +kubebuilder:validation:XValidation:rule="self.type != 'ca' || has(self.ca)",message="ca issuers require the ca field"
+// +kubebuilder:validation:XValidation:rule="!self.type.startsWith('acme') || has(self.acme)",message="acme issuers require the acme field"
+type Issuer struct {
+	// Name of the ClusterIssuer.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Type of the issuer.  Must be one of selfSigned, ca, acmeHTTP01 or acmeDNS01Route53.
+	// +kubebuilder:validation:Enum=selfSigned;ca;acmeHTTP01;acmeDNS01Route53
+	// +kubebuilder:validation:Required
+	Type string `json:"type"`
+
+	// Certificate authority of a ca issuer.
+	// +kubebuilder:validation:Optional
+	CA IssuerCA `json:"ca,omitempty"`
+
+	// ACME account and server of an acmeHTTP01 or acmeDNS01Route53 issuer.
+	// +kubebuilder:validation:Optional
+	ACME IssuerACME `json:"acme,omitempty"`
+}
+
+// IssuerCA defines the certificate authority of a ca issuer.
+type IssuerCA struct {
+	// Name of the Secret holding the tls.crt and tls.key of the certificate authority.  The Secret
+	// must exist in the namespace of the certificates capability.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Required
+	SecretName string `json:"secretName"`
+}
+
+// IssuerACME defines the ACME account and server of an ACME issuer.
+type IssuerACME struct {
+	// Directory URL of the ACME server.
+	// +kubebuilder:default="https://acme-v02.api.letsencrypt.org/directory"
+	// +kubebuilder:validation:Optional
+	Server string `json:"server,omitempty"`
+
+	// Email address which is registered with the ACME account.
+	// +kubebuilder:validation:Optional
+	Email string `json:"email,omitempty"`
+
+	// Name of the Secret, in the namespace of the certificates capability, which stores the private
+	// key of the ACME account.  Defaults to the name of the issuer suffixed with -account-key.
+	// +kubebuilder:validation:Optional
+	PrivateKeySecretName string `json:"privateKeySecretName,omitempty"`
+
+	// PEM encoded certificate authorities which are trusted when connecting to the ACME server, such
+	// as the root of a local Pebble server.
+	// +kubebuilder:validation:Optional
+	CABundle string `json:"caBundle,omitempty"`
+
+	// Whether to skip verification of the TLS certificate of the ACME server.  This should only be
+	// used for testing against a local ACME server.
+	// +kubebuilder:validation:Optional
+	SkipTLSVerify bool `json:"skipTLSVerify,omitempty"`
+
+	// HTTP01 challenge solver of an acmeHTTP01 issuer.
+	// +kubebuilder:validation:Optional
+	HTTP01 IssuerACMEHTTP01 `json:"http01,omitempty"`
+
+	// Route53 DNS01 challenge solver of an acmeDNS01Route53 issuer.  Changes to Route53 are made
+	// with the ambient credentials of the AWS role of the certificates capability (certificates.aws.roleARN),
+	// so no credentials are set on the issuer.
+	// +kubebuilder:validation:Optional
+	Route53 IssuerACMERoute53 `json:"route53,omitempty"`
+}
+
+// IssuerACMEHTTP01 defines the HTTP01 challenge solver of an ACME issuer.
+type IssuerACMEHTTP01 struct {
+	// Name of the IngressClass which serves the HTTP01 challenges.  When not set, the default
+	// IngressClass of the cluster is used.
+	// +kubebuilder:validation:Optional
+	IngressClassName string `json:"ingressClassName,omitempty"`
+}
+
+// IssuerACMERoute53 defines the Route53 DNS01 challenge solver of an ACME issuer.
+type IssuerACMERoute53 struct {
+	// AWS region of Route53.
+	// +kubebuilder:default="us-east-1"
+	// +kubebuilder:validation:Optional
+	Region string `json:"region,omitempty"`
+
+	// ID of the hosted zone which is updated to solve challenges.  When not set, the hosted zone is
+	// discovered from the name being validated.
+	// +kubebuilder:validation:Optional
+	HostedZoneID string `json:"hostedZoneID,omitempty"`
+}
+
+// IssuerStatus defines the observed readiness of an issuer.
+type IssuerStatus struct {
+	// Name of the ClusterIssuer.
+	Name string `json:"name"`
+
+	// Whether the ClusterIssuer is ready to issue certificates.
+	Ready bool `json:"ready"`
+
+	// Reason that the ClusterIssuer is not ready.
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// Metrics defines how the metrics endpoints of the platform operators are served and scraped.
+// +kubebuilder:validation:XValidation:rule="!self.serviceMonitors.enabled || self.certificate.enabled",message="serviceMonitors require the metrics certificate, so that the metrics endpoints are verified"
+type Metrics struct {
+	// Certificate which is requested from cert-manager for the metrics endpoint of each operator,
+	// once the certificates capability is ready.  Until then, the metrics endpoints are served with
+	// self-signed certificates.
+	// +kubebuilder:default={}
+	// +kubebuilder:validation:Optional
+	Certificate MetricsCertificate `json:"certificate,omitempty"`
+
+	// Prometheus ServiceMonitors which scrape the metrics endpoints of the capability operators.
+	// Requires the Prometheus operator.
+	// +kubebuilder:default={}
+	// +kubebuilder:validation:Optional
+	ServiceMonitors MetricsServiceMonitors `json:"serviceMonitors,omitempty"`
+}
+
+// MetricsCertificate defines the certificate which is requested from cert-manager for the metrics
+// endpoint of each operator.
+// +kubebuilder:validation:XValidation:rule="!self.enabled || has(self.issuerName)",message="issuerName is required when the metrics certificates are enabled"
+type MetricsCertificate struct {
+	// Whether the certificates are requested.
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Name of the ClusterIssuer which issues the certificates, which is required when the
+	// certificates are requested.  The issuer must populate the ca.crt key of the certificate
+	// Secrets, against which the ServiceMonitors verify the endpoints.  The platform-root-ca issuer
+	// only exists when the cloud of the PlatformConfig is local.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Optional
+	IssuerName string `json:"issuerName,omitempty"`
+}
+
+// MetricsServiceMonitors defines the Prometheus ServiceMonitors of the capability operators.
+type MetricsServiceMonitors struct {
+	// Whether the ServiceMonitors are created.
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// MetricsCertificateStatus defines the observed state of the certificate of a metrics endpoint.
+type MetricsCertificateStatus struct {
+	// Name of the Certificate.
+	Name string `json:"name"`
+
+	// Whether the certificate is issued.
+	Ready bool `json:"ready"`
+
+	// Reason that the certificate is not issued.
+	Message string `json:"message,omitempty"`
+}
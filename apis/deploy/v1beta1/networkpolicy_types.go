@@ -0,0 +1,98 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// NetworkPolicy defines the network isolation of the namespaces which are managed by a workload.
+type NetworkPolicy struct {
+	// Mode of network isolation.  When none, no network policies are created.  When default,
+	// ingress is denied except for the flows which are required by the platform components.  When
+	// strict, egress is additionally denied except for DNS and the API server.  The egress to the
+	// API server is allowed to any destination on ports 443 and 6443 unless apiServerCIDRs is set.
+	// +kubebuilder:default="default"
+	// +kubebuilder:validation:Enum=none;default;strict
+	// +kubebuilder:validation:Optional
+	Mode string `json:"mode,omitempty"`
+
+	// Pods which are allowed to scrape the metrics of the platform components.  Ignored when mode
+	// is none.
+	// +kubebuilder:default={"podLabels":{"app.kubernetes.io/name":"prometheus"}}
+	// +kubebuilder:validation:Optional
+	MetricsScrapers NetworkPolicyPeer `json:"metricsScrapers,omitempty"`
+
+	// CIDRs of the API server endpoints (e.g. the addresses of the endpoints of the kubernetes
+	// service in the default namespace).  Only applies when mode is strict.  When empty, the
+	// egress to the API server is allowed to any destination on ports 443 and 6443, as the
+	// address of the API server is not known ahead of time.
+	// +kubebuilder:validation:Optional
+	APIServerCIDRs []string `json:"apiServerCIDRs,omitempty"`
+
+	// Additional ingress rules to allow.  Ignored when mode is none.
+	// +kubebuilder:validation:Optional
+	AdditionalIngress []NetworkPolicyRule `json:"additionalIngress,omitempty"`
+
+	// Additional egress rules to allow.  Only applies when mode is strict, as egress is otherwise
+	// not restricted.
+	// +kubebuilder:validation:Optional
+	AdditionalEgress []NetworkPolicyRule `json:"additionalEgress,omitempty"`
+}
+
+// NetworkPolicyRule defines a flow which is allowed to or from the pods in a managed namespace.
+type NetworkPolicyRule struct {
+	// Ports which are allowed.  All ports are allowed when empty.
+	// +kubebuilder:validation:Optional
+	Ports []NetworkPolicyPort `json:"ports,omitempty"`
+
+	// CIDRs which are allowed.
+	// +kubebuilder:validation:Optional
+	CIDRs []string `json:"cidrs,omitempty"`
+
+	// Labels of the namespaces whose pods are allowed.  Selects all namespaces when empty and
+	// podLabels are set.
+	// +kubebuilder:validation:Optional
+	NamespaceLabels map[string]string `json:"namespaceLabels,omitempty"`
+
+	// Labels of the pods which are allowed.  Selects all pods when empty and namespaceLabels
+	// are set.
+	// +kubebuilder:validation:Optional
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+}
+
+// NetworkPolicyPeer defines a set of pods which a network policy rule allows.
+type NetworkPolicyPeer struct {
+	// Labels of the namespaces whose pods are allowed.  Selects all namespaces when empty.
+	// +kubebuilder:validation:Optional
+	NamespaceLabels map[string]string `json:"namespaceLabels,omitempty"`
+
+	// Labels of the pods which are allowed.  Selects all pods when empty.
+	// +kubebuilder:validation:Optional
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+}
+
+// NetworkPolicyPort defines a port which is allowed by a network policy rule.
+type NetworkPolicyPort struct {
+	// Port number which is allowed.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:validation:Required
+	Port int `json:"port"`
+
+	// Protocol of the port which is allowed.
+	// +kubebuilder:default="TCP"
+	// +kubebuilder:validation:Enum=TCP;UDP;SCTP
+	// +kubebuilder:validation:Optional
+	Protocol string `json:"protocol,omitempty"`
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// Override defines a patch which is applied to a generated child resource as it is rendered, for
+// settings which are not otherwise modeled by the specification.  A patch may only set fields which
+// are defined by the schema of the child resource, as the API server would otherwise drop them, and
+// is applied before the common labels and annotations.
+type Override struct {
+	// Child resource which the patch is applied to.
+	// +kubebuilder:validation:Required
+	Target OverrideTarget `json:"target"`
+
+	// Type of the patch.  Must be one of strategic, for a strategic merge patch, or json6902, for a
+	// JSON patch.  Strategic merge patches of custom resources are applied as JSON merge patches.
+	// +kubebuilder:default="strategic"
+	// +kubebuilder:validation:Enum=strategic;json6902
+	// +kubebuilder:validation:Optional
+	Type string `json:"type,omitempty"`
+
+	// Patch in either YAML or JSON format.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Required
+	Patch string `json:"patch"`
+}
+
+// OverrideTarget identifies a generated child resource.
+type OverrideTarget struct {
+	// API group of the child resource, which is empty for the core API group.
+	// +kubebuilder:validation:Optional
+	Group string `json:"group,omitempty"`
+
+	// Kind of the child resource.
+	// +kubebuilder:validation:Required
+	Kind string `json:"kind"`
+
+	// Name of the child resource.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Namespace of the child resource.  When not set, child resources in any namespace are targeted.
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// OverrideStatus defines the result of applying an override.
+type OverrideStatus struct {
+	// Child resource which the patch is applied to.
+	Target OverrideTarget `json:"target"`
+
+	// Whether the patch was applied.
+	Applied bool `json:"applied"`
+
+	// Reason that the patch was not applied.
+	Message string `json:"message,omitempty"`
+}
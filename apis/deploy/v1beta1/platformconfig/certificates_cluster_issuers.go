@@ -0,0 +1,73 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platformconfig
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+	"github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1/platformconfig/mutate"
+	"github.com/tbd-paas/platform-config-operator/internal/issuers"
+	"github.com/tbd-paas/platform-config-operator/internal/readiness"
+	"github.com/tbd-paas/platform-config-operator/internal/version"
+)
+
+// CreateClusterIssuersPlatformCertificates creates the ClusterIssuer resources with names from
+// parent.Spec.Certificates.Issuers, once the CertManager resource is ready.
+func CreateClusterIssuersPlatformCertificates(
+	parent *deployv1beta1.PlatformConfig,
+	reconciler workload.Reconciler,
+	req *workload.Request,
+) ([]client.Object, error) {
+
+	if parent.Spec.Certificates.Enabled != true {
+		return []client.Object{}, nil
+	}
+
+	if len(parent.Spec.Certificates.Issuers) == 0 {
+		return []client.Object{}, nil
+	}
+
+	resourceObjects := issuers.Generate(
+		parent.Spec.Certificates,
+		map[string]interface{}{
+			"capabilities.tbd.io/capability":       "platform-config",
+			"capabilities.tbd.io/version":          version.CapabilityVersion,
+			"capabilities.tbd.io/platform-version": version.Version,
+			"app.kubernetes.io/version":            version.Version,
+			"app.kubernetes.io/part-of":            "platform",
+			"app.kubernetes.io/managed-by":         "platform-config-operator",
+		},
+	)
+
+	// the ClusterIssuers are admitted once cert-manager is ready, until which only those which
+	// already exist are kept
+	ready, err := issuers.Ready(reconciler, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ready {
+		if resourceObjects, err = readiness.Existing(reconciler, req, resourceObjects); err != nil {
+			return nil, err
+		}
+	}
+
+	return mutate.Overrides(parent, reconciler, req, resourceObjects...), nil
+}
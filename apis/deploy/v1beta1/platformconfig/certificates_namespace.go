@@ -22,45 +22,59 @@ import (
 
 	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
 
-	deployv1alpha1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1alpha1"
-	"github.com/tbd-paas/platform-config-operator/apis/deploy/v1alpha1/platformconfig/mutate"
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+	"github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1/platformconfig/mutate"
+	"github.com/tbd-paas/platform-config-operator/internal/version"
 )
 
 // +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch;create;update;patch;delete
 
-// CreateNamespacePlatformCertificatesNamespace creates the Namespace resource with name parent.Spec.Platform.Certificates.Namespace.
+// CreateNamespacePlatformCertificatesNamespace creates the Namespace resource with name parent.Spec.Certificates.Namespace.
 func CreateNamespacePlatformCertificatesNamespace(
-	parent *deployv1alpha1.PlatformConfig,
+	parent *deployv1beta1.PlatformConfig,
 	reconciler workload.Reconciler,
 	req *workload.Request,
 ) ([]client.Object, error) {
 
+	if parent.Spec.Certificates.Enabled != true {
+		return []client.Object{}, nil
+	}
+
 	var resourceObj = &unstructured.Unstructured{
 		Object: map[string]interface{}{
 			"apiVersion": "v1",
 			"kind":       "Namespace",
 			"metadata": map[string]interface{}{
-				// controlled by field: platform.certificates.namespace
+				// controlled by field: certificates.namespace
 				// Namespace where
 				//  the capability components will be deployed.
-				"name": parent.Spec.Platform.Certificates.Namespace,
+				"name": parent.Spec.Certificates.Namespace,
 				"labels": map[string]interface{}{
 					"capabilities.tbd.io/capability":                "platform-config",
-					"capabilities.tbd.io/version":                   "v0.0.1",
-					"capabilities.tbd.io/platform-version":          "unstable",
-					"app.kubernetes.io/version":                     "unstable",
+					"capabilities.tbd.io/version":                   version.CapabilityVersion,
+					"capabilities.tbd.io/platform-version":          version.Version,
+					"app.kubernetes.io/version":                     version.Version,
 					"app.kubernetes.io/part-of":                     "platform",
 					"app.kubernetes.io/managed-by":                  "platform-config-operator",
 					"certificates.platform.tbd.io/inject-ca-bundle": "true",
+					// controlled by field: certificates.podSecurity.enforce
+					"pod-security.kubernetes.io/enforce":         parent.Spec.Certificates.PodSecurity.Enforce,
+					"pod-security.kubernetes.io/enforce-version": "latest",
+					// controlled by field: certificates.podSecurity.audit
+					"pod-security.kubernetes.io/audit":         parent.Spec.Certificates.PodSecurity.Audit,
+					"pod-security.kubernetes.io/audit-version": "latest",
+					// controlled by field: certificates.podSecurity.warn
+					"pod-security.kubernetes.io/warn":         parent.Spec.Certificates.PodSecurity.Warn,
+					"pod-security.kubernetes.io/warn-version": "latest",
 				},
 				"annotations": map[string]interface{}{
 					"operator-builder.nukleros.io/ready-path":  ".status.created",
 					"operator-builder.nukleros.io/ready-value": "true",
-					// controlled by field: platform.certificates.deploymentSize
+					// controlled by field: certificates.deploymentSize
 					// Size of the
 					//  deployment for the underlying capability.  Must be one of small, medium, or large.
 					//  +kubebuilder:validation:Enum:small;medium;large
-					"certificates.platform.tbd.io/deployment-size": parent.Spec.Platform.Certificates.DeploymentSize,
+					"certificates.platform.tbd.io/deployment-size": parent.Spec.Certificates.DeploymentSize,
 				},
 			},
 		},
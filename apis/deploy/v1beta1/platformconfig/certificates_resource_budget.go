@@ -0,0 +1,89 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platformconfig
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+	"github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1/platformconfig/mutate"
+	"github.com/tbd-paas/platform-config-operator/internal/budgets"
+	"github.com/tbd-paas/platform-config-operator/internal/version"
+)
+
+// CreateResourceBudgetPlatformCertificatesNamespace creates the ResourceQuota and LimitRange resources which enforce the resource budget of the
+// Namespace with name parent.Spec.Certificates.Namespace.
+func CreateResourceBudgetPlatformCertificatesNamespace(
+	parent *deployv1beta1.PlatformConfig,
+	reconciler workload.Reconciler,
+	req *workload.Request,
+) ([]client.Object, error) {
+
+	if parent.Spec.Certificates.Enabled != true {
+		return []client.Object{}, nil
+	}
+
+	// the quota accounts for the components which the capability custom resources deploy
+	certManager, err := CreateCertManagerConfig(parent, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	trustManager, err := CreateTrustManagerConfig(parent, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	certManagerComponents, err := budgets.Components(
+		certManager,
+		[]string{"spec", "injector"},
+		[]string{"spec", "controller"},
+		[]string{"spec", "webhook"},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	trustManagerComponents, err := budgets.Components(trustManager, []string{"spec", "controller"})
+	if err != nil {
+		return nil, err
+	}
+
+	components := append(certManagerComponents, trustManagerComponents...)
+
+	resourceObjects, err := budgets.Generate(
+		parent.Spec.Certificates.Namespace,
+		map[string]interface{}{
+			"capabilities.tbd.io/capability":       "platform-config",
+			"capabilities.tbd.io/version":          version.CapabilityVersion,
+			"capabilities.tbd.io/platform-version": version.Version,
+			"app.kubernetes.io/version":            version.Version,
+			"app.kubernetes.io/part-of":            "platform",
+			"app.kubernetes.io/managed-by":         "platform-config-operator",
+		},
+		parent.Spec.Certificates.DeploymentSize,
+		parent.Spec.Certificates.ResourceBudget,
+		components,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return mutate.Overrides(parent, reconciler, req, resourceObjects...), nil
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platformconfig
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+	"github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1/platformconfig/mutate"
+	"github.com/tbd-paas/platform-config-operator/internal/issuers"
+	"github.com/tbd-paas/platform-config-operator/internal/readiness"
+	"github.com/tbd-paas/platform-config-operator/internal/rootca"
+	"github.com/tbd-paas/platform-config-operator/internal/version"
+)
+
+// CreateClusterIssuerPlatformRootCA creates the ClusterIssuer resource with name platform-root-ca,
+// which issues certificates from the private root CA of a local cloud.  The root CA itself is
+// generated and rotated by the Root-CA phase, once the child resources are created.
+func CreateClusterIssuerPlatformRootCA(
+	parent *deployv1beta1.PlatformConfig,
+	reconciler workload.Reconciler,
+	req *workload.Request,
+) ([]client.Object, error) {
+
+	if parent.Spec.Certificates.Enabled != true || parent.Spec.Cloud.Local != true {
+		return []client.Object{}, nil
+	}
+
+	var resourceObj = &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "cert-manager.io/v1",
+			"kind":       "ClusterIssuer",
+			"metadata": map[string]interface{}{
+				"name": rootca.IssuerName,
+				"labels": map[string]interface{}{
+					"capabilities.tbd.io/capability":       "platform-config",
+					"capabilities.tbd.io/version":          version.CapabilityVersion,
+					"capabilities.tbd.io/platform-version": version.Version,
+					"app.kubernetes.io/version":            version.Version,
+					"app.kubernetes.io/part-of":            "platform",
+					"app.kubernetes.io/managed-by":         "platform-config-operator",
+				},
+			},
+			"spec": map[string]interface{}{
+				"ca": map[string]interface{}{
+					"secretName": rootca.SecretName,
+				},
+			},
+		},
+	}
+
+	// the ClusterIssuer is admitted once cert-manager is ready, until which it is only kept if it
+	// already exists
+	ready, err := issuers.Ready(reconciler, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceObjects := []client.Object{resourceObj}
+
+	if !ready {
+		if resourceObjects, err = readiness.Existing(reconciler, req, resourceObjects); err != nil {
+			return nil, err
+		}
+	}
+
+	return mutate.Overrides(parent, reconciler, req, resourceObjects...), nil
+}
@@ -22,19 +22,24 @@ import (
 
 	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
 
-	deployv1alpha1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1alpha1"
-	"github.com/tbd-paas/platform-config-operator/apis/deploy/v1alpha1/platformconfig/mutate"
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+	"github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1/platformconfig/mutate"
+	"github.com/tbd-paas/platform-config-operator/internal/version"
 )
 
 // +kubebuilder:rbac:groups=certificates.platform.tbd.io,resources=trustmanagers,verbs=get;list;watch;create;update;patch;delete
 
 // CreateTrustManagerConfig creates the TrustManager resource with name config.
 func CreateTrustManagerConfig(
-	parent *deployv1alpha1.PlatformConfig,
+	parent *deployv1beta1.PlatformConfig,
 	reconciler workload.Reconciler,
 	req *workload.Request,
 ) ([]client.Object, error) {
 
+	if parent.Spec.Certificates.Enabled != true {
+		return []client.Object{}, nil
+	}
+
 	var resourceObj = &unstructured.Unstructured{
 		Object: map[string]interface{}{
 			"apiVersion": "certificates.platform.tbd.io/v1alpha1",
@@ -43,9 +48,9 @@ func CreateTrustManagerConfig(
 				"name": "config",
 				"labels": map[string]interface{}{
 					"capabilities.tbd.io/capability":       "platform-config",
-					"capabilities.tbd.io/version":          "v0.0.1",
-					"capabilities.tbd.io/platform-version": "unstable",
-					"app.kubernetes.io/version":            "unstable",
+					"capabilities.tbd.io/version":          version.CapabilityVersion,
+					"capabilities.tbd.io/platform-version": version.Version,
+					"app.kubernetes.io/version":            version.Version,
 					"app.kubernetes.io/part-of":            "platform",
 					"app.kubernetes.io/managed-by":         "platform-config-operator",
 				},
@@ -55,7 +60,7 @@ func CreateTrustManagerConfig(
 				},
 			},
 			"spec": map[string]interface{}{
-				"namespace": parent.Spec.Platform.Certificates.Namespace, //  controlled by field: platform.certificates.namespace
+				"namespace": parent.Spec.Certificates.Namespace, //  controlled by field: certificates.namespace
 				"controller": map[string]interface{}{
 					"replicas": 2,
 					"image":    "quay.io/jetstack/trust-manager:v0.9.2",
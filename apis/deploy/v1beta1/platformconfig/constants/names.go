@@ -20,8 +20,8 @@ package constants
 // package to prevent import cycle errors when attempting to reference the names from other
 // packages (e.g. mutate).
 const (
-	NamespacePlatformCertificatesNamespace = "parent.Spec.Platform.Certificates.Namespace"
-	NamespacePlatformIdentityNamespace     = "parent.Spec.Platform.Identity.Namespace"
+	NamespacePlatformCertificatesNamespace = "parent.Spec.Certificates.Namespace"
+	NamespacePlatformIdentityNamespace     = "parent.Spec.Identity.Namespace"
 	CertManagerConfig                      = "config"
 	TrustManagerConfig                     = "config"
 	AWSPodIdentityWebhookConfig            = "config"
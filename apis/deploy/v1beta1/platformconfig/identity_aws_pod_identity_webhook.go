@@ -22,19 +22,26 @@ import (
 
 	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
 
-	deployv1alpha1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1alpha1"
-	"github.com/tbd-paas/platform-config-operator/apis/deploy/v1alpha1/platformconfig/mutate"
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+	"github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1/platformconfig/mutate"
+	"github.com/tbd-paas/platform-config-operator/internal/version"
 )
 
 // +kubebuilder:rbac:groups=identity.platform.tbd.io,resources=awspodidentitywebhooks,verbs=get;list;watch;create;update;patch;delete
 
-// CreateAWSPodIdentityWebhookConfig creates the AWSPodIdentityWebhook resource with name config.
+// CreateAWSPodIdentityWebhookConfig creates the AWSPodIdentityWebhook resource with name config.  The
+// service accounts which are selected by the role bindings are annotated by the Role-Bindings phase,
+// regardless of whether the cloud is local.
 func CreateAWSPodIdentityWebhookConfig(
-	parent *deployv1alpha1.PlatformConfig,
+	parent *deployv1beta1.PlatformConfig,
 	reconciler workload.Reconciler,
 	req *workload.Request,
 ) ([]client.Object, error) {
 
+	if parent.Spec.Identity.Enabled != true {
+		return []client.Object{}, nil
+	}
+
 	if parent.Spec.Cloud.Type != "aws" {
 		return []client.Object{}, nil
 	}
@@ -53,9 +60,9 @@ func CreateAWSPodIdentityWebhookConfig(
 				"name": "config",
 				"labels": map[string]interface{}{
 					"capabilities.tbd.io/capability":       "platform-config",
-					"capabilities.tbd.io/version":          "v0.0.1",
-					"capabilities.tbd.io/platform-version": "unstable",
-					"app.kubernetes.io/version":            "unstable",
+					"capabilities.tbd.io/version":          version.CapabilityVersion,
+					"capabilities.tbd.io/platform-version": version.Version,
+					"app.kubernetes.io/version":            version.Version,
 					"app.kubernetes.io/part-of":            "platform",
 					"app.kubernetes.io/managed-by":         "platform-config-operator",
 				},
@@ -65,7 +72,7 @@ func CreateAWSPodIdentityWebhookConfig(
 				},
 			},
 			"spec": map[string]interface{}{
-				"namespace": parent.Spec.Platform.Identity.Namespace, //  controlled by field: platform.identity.namespace
+				"namespace": parent.Spec.Identity.Namespace, //  controlled by field: identity.namespace
 				"replicas":  2,
 				"image":     "amazon/amazon-eks-pod-identity-webhook:v0.5.3",
 				"resources": map[string]interface{}{
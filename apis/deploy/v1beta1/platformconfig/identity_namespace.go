@@ -22,50 +22,64 @@ import (
 
 	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
 
-	deployv1alpha1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1alpha1"
-	"github.com/tbd-paas/platform-config-operator/apis/deploy/v1alpha1/platformconfig/mutate"
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+	"github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1/platformconfig/mutate"
+	"github.com/tbd-paas/platform-config-operator/internal/version"
 )
 
 // +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch;create;update;patch;delete
 
-// CreateNamespacePlatformIdentityNamespace creates the Namespace resource with name parent.Spec.Platform.Identity.Namespace.
+// CreateNamespacePlatformIdentityNamespace creates the Namespace resource with name parent.Spec.Identity.Namespace.
 func CreateNamespacePlatformIdentityNamespace(
-	parent *deployv1alpha1.PlatformConfig,
+	parent *deployv1beta1.PlatformConfig,
 	reconciler workload.Reconciler,
 	req *workload.Request,
 ) ([]client.Object, error) {
 
+	if parent.Spec.Identity.Enabled != true {
+		return []client.Object{}, nil
+	}
+
 	var resourceObj = &unstructured.Unstructured{
 		Object: map[string]interface{}{
 			"apiVersion": "v1",
 			"kind":       "Namespace",
 			"metadata": map[string]interface{}{
-				// controlled by field: platform.identity.namespace
+				// controlled by field: identity.namespace
 				// Namespace where
 				//  the capability components will be deployed.
-				"name": parent.Spec.Platform.Identity.Namespace,
+				"name": parent.Spec.Identity.Namespace,
 				"labels": map[string]interface{}{
 					"capabilities.tbd.io/capability":                "platform-config",
-					"capabilities.tbd.io/version":                   "v0.0.1",
-					"capabilities.tbd.io/platform-version":          "unstable",
-					"app.kubernetes.io/version":                     "unstable",
+					"capabilities.tbd.io/version":                   version.CapabilityVersion,
+					"capabilities.tbd.io/platform-version":          version.Version,
+					"app.kubernetes.io/version":                     version.Version,
 					"app.kubernetes.io/part-of":                     "platform",
 					"app.kubernetes.io/managed-by":                  "platform-config-operator",
 					"certificates.platform.tbd.io/inject-ca-bundle": "true",
+					// controlled by field: identity.podSecurity.enforce
+					"pod-security.kubernetes.io/enforce":         parent.Spec.Identity.PodSecurity.Enforce,
+					"pod-security.kubernetes.io/enforce-version": "latest",
+					// controlled by field: identity.podSecurity.audit
+					"pod-security.kubernetes.io/audit":         parent.Spec.Identity.PodSecurity.Audit,
+					"pod-security.kubernetes.io/audit-version": "latest",
+					// controlled by field: identity.podSecurity.warn
+					"pod-security.kubernetes.io/warn":         parent.Spec.Identity.PodSecurity.Warn,
+					"pod-security.kubernetes.io/warn-version": "latest",
 				},
 				"annotations": map[string]interface{}{
 					"operator-builder.nukleros.io/ready-path":  ".status.created",
 					"operator-builder.nukleros.io/ready-value": "true",
 					// controlled by field: cloud.type
 					// controlled by field: cloud.local
-					// controlled by field: platform.identity.deploymentSize
+					// controlled by field: identity.deploymentSize
 					//  +kubebuilder:validation:Enum=aws
 					//  Underlying cloud type this platform is deployed upon.  Currently, only AWS is supported.
 					//  Whether this cloud is deployed as a local cloud to use for testing scenarios.
 					// Size of the
 					//  +kubebuilder:validation:Enum:small;medium;large
 					//  deployment for the underlying capability.  Must be one of small, medium, or large.
-					"identity.platform.tbd.io/deployment-size": parent.Spec.Platform.Identity.DeploymentSize,
+					"identity.platform.tbd.io/deployment-size": parent.Spec.Identity.DeploymentSize,
 				},
 			},
 		},
@@ -0,0 +1,173 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platformconfig
+
+import (
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+	"github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1/platformconfig/mutate"
+	"github.com/tbd-paas/platform-config-operator/internal/oidcdiscovery"
+	"github.com/tbd-paas/platform-config-operator/internal/version"
+)
+
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+
+// CreateConfigMapServiceAccountIssuerDiscovery creates the ConfigMap resource with name from
+// parent.Spec.Identity.ServiceAccountIssuerDiscovery.ConfigMapName, which holds the discovery
+// documents of the service account issuer of a local cloud.  The documents are retrieved from the
+// API server at most once per refresh interval, and the documents which were last published are
+// kept while the API server does not serve them.
+func CreateConfigMapServiceAccountIssuerDiscovery(
+	parent *deployv1beta1.PlatformConfig,
+	reconciler workload.Reconciler,
+	req *workload.Request,
+) ([]client.Object, error) {
+
+	discovery := parent.Spec.Identity.ServiceAccountIssuerDiscovery
+
+	if parent.Spec.Identity.Enabled != true ||
+		parent.Spec.Cloud.Type != "aws" ||
+		parent.Spec.Cloud.Local != true ||
+		discovery.Enabled != true {
+		setServiceAccountIssuerDiscoveryStatus(req, nil)
+
+		return []client.Object{}, nil
+	}
+
+	// the CLI does not render the documents, as they are specific to the cluster
+	if reconciler == nil || req == nil {
+		return []client.Object{}, nil
+	}
+
+	discovered, err := oidcdiscovery.Fetch(reconciler, req)
+	if err != nil {
+		if !errors.Is(err, oidcdiscovery.ErrUnavailable) {
+			return nil, err
+		}
+
+		// the documents which were last published continue to be served while the API server does
+		// not serve them, as this is often temporary, e.g. while the API server restarts
+		published, publishedErr := oidcdiscovery.Published(
+			reconciler,
+			req,
+			client.ObjectKey{Namespace: parent.Spec.Identity.Namespace, Name: discovery.ConfigMapName},
+		)
+		if publishedErr != nil {
+			return nil, publishedErr
+		}
+
+		if published == nil {
+			setServiceAccountIssuerDiscoveryStatus(req, &deployv1beta1.ServiceAccountIssuerDiscoveryStatus{
+				Message: err.Error(),
+			})
+
+			return []client.Object{}, nil
+		}
+
+		setServiceAccountIssuerDiscoveryStatus(req, &deployv1beta1.ServiceAccountIssuerDiscoveryStatus{
+			Issuer:    published.Issuer,
+			Published: true,
+			Message:   fmt.Sprintf("the previously published documents are served, %s", err.Error()),
+		})
+
+		return discoveryConfigMap(parent, reconciler, req, published), nil
+	}
+
+	// tokens are only validated against documents which are published for their issuer
+	if discovery.IssuerURL != "" && discovery.IssuerURL != discovered.Issuer {
+		setServiceAccountIssuerDiscoveryStatus(req, &deployv1beta1.ServiceAccountIssuerDiscoveryStatus{
+			Issuer: discovered.Issuer,
+			Message: fmt.Sprintf(
+				"the issuer URL %s does not match the issuer %s of the API server, as set by its --service-account-issuer flag",
+				discovery.IssuerURL,
+				discovered.Issuer,
+			),
+		})
+
+		return []client.Object{}, nil
+	}
+
+	published, err := oidcdiscovery.Publish(discovered)
+	if err != nil {
+		return nil, err
+	}
+
+	setServiceAccountIssuerDiscoveryStatus(req, &deployv1beta1.ServiceAccountIssuerDiscoveryStatus{
+		Issuer:    published.Issuer,
+		Published: true,
+	})
+
+	return discoveryConfigMap(parent, reconciler, req, published), nil
+}
+
+// discoveryConfigMap returns the ConfigMap which holds the published discovery documents.
+func discoveryConfigMap(
+	parent *deployv1beta1.PlatformConfig,
+	reconciler workload.Reconciler,
+	req *workload.Request,
+	published *oidcdiscovery.Documents,
+) []client.Object {
+	discovery := parent.Spec.Identity.ServiceAccountIssuerDiscovery
+
+	var resourceObj = &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				// controlled by field: identity.serviceAccountIssuerDiscovery.configMapName
+				"name":      discovery.ConfigMapName,
+				"namespace": parent.Spec.Identity.Namespace, //  controlled by field: identity.namespace
+				"labels": map[string]interface{}{
+					"capabilities.tbd.io/capability":       "platform-config",
+					"capabilities.tbd.io/version":          version.CapabilityVersion,
+					"capabilities.tbd.io/platform-version": version.Version,
+					"app.kubernetes.io/version":            version.Version,
+					"app.kubernetes.io/part-of":            "platform",
+					"app.kubernetes.io/managed-by":         "platform-config-operator",
+				},
+			},
+			"data": map[string]interface{}{
+				oidcdiscovery.ConfigurationKey: string(published.Configuration),
+				oidcdiscovery.KeysKey:          string(published.Keys),
+			},
+		},
+	}
+
+	return mutate.Overrides(parent, reconciler, req, resourceObj)
+}
+
+// setServiceAccountIssuerDiscoveryStatus sets the state of the publication of the service account
+// issuer discovery documents upon the workload of the request, if any.
+func setServiceAccountIssuerDiscoveryStatus(
+	req *workload.Request,
+	discoveryStatus *deployv1beta1.ServiceAccountIssuerDiscoveryStatus,
+) {
+	if req == nil {
+		return
+	}
+
+	if component, err := ConvertWorkload(req.Workload); err == nil {
+		component.SetServiceAccountIssuerDiscoveryStatus(discoveryStatus)
+	}
+}
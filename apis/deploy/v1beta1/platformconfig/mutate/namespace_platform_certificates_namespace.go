@@ -21,21 +21,22 @@ import (
 
 	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
 
-	deployv1alpha1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1alpha1"
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
 )
 
-// MutateNamespacePlatformCertificatesNamespace mutates the Namespace resource with name parent.Spec.Platform.Certificates.Namespace.
+// MutateNamespacePlatformCertificatesNamespace mutates the Namespace resource with name parent.Spec.Certificates.Namespace.
 func MutateNamespacePlatformCertificatesNamespace(
 	original client.Object,
-	parent *deployv1alpha1.PlatformConfig,
+	parent *deployv1beta1.PlatformConfig,
 	reconciler workload.Reconciler, req *workload.Request,
 ) ([]client.Object, error) {
-	// if either the reconciler or request are found to be nil, return the base object.
+	// if either the reconciler or request are found to be nil, return the base object with the
+	// overrides applied.
 	if reconciler == nil || req == nil {
-		return []client.Object{original}, nil
+		return Overrides(parent, reconciler, req, original), nil
 	}
 
 	// mutation logic goes here
 
-	return []client.Object{original}, nil
+	return Overrides(parent, reconciler, req, original), nil
 }
@@ -0,0 +1,269 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platformconfig
+
+import (
+	"errors"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+	"github.com/tbd-paas/platform-config-operator/internal/disruption"
+	"github.com/tbd-paas/platform-config-operator/internal/metadata"
+	"github.com/tbd-paas/platform-config-operator/internal/mutate"
+	"github.com/tbd-paas/platform-config-operator/internal/podsecurity"
+)
+
+// samplePlatformConfig is a sample containing all fields
+const samplePlatformConfig = `apiVersion: deploy.platform.tbd.io/v1beta1
+kind: PlatformConfig
+metadata:
+  name: platformconfig-sample
+spec:
+  certificates:
+    enabled: true
+    namespace: "tbd-certificates-system"
+    deploymentSize: "small"
+    podSecurity:
+      enforce: "restricted"
+      audit: "restricted"
+      warn: "restricted"
+    aws:
+      roleARN: ""
+    issuers:
+      - name: "selfsigned"
+        type: "selfSigned"
+    rootCA:
+      keyAlgorithm: "ECDSA"
+      keySize: 256
+      validity: "87600h"
+      renewBefore: "720h"
+      subject:
+        commonName: "tbd platform root CA"
+    trust:
+      bundles:
+        - name: "platform-ca-bundle"
+          sources:
+            useDefaultCAs: false
+            platformRootCA: true
+          target:
+            kind: "ConfigMap"
+            key: "ca-bundle.crt"
+      injection:
+        namespaceSelector:
+          certificates.platform.tbd.io/inject-ca-bundle: "true"
+        includeNamespaces: []
+        excludeNamespaces: []
+  identity:
+    enabled: true
+    namespace: "tbd-identity-system"
+    deploymentSize: "small"
+    podSecurity:
+      enforce: "restricted"
+      audit: "restricted"
+      warn: "restricted"
+  cloud:
+    type: "aws"
+    local: true
+  networkPolicy:
+    mode: "default"
+    metricsScrapers:
+      podLabels:
+        app.kubernetes.io/name: "prometheus"
+`
+
+// samplePlatformConfigRequired is a sample containing only required fields
+const samplePlatformConfigRequired = `apiVersion: deploy.platform.tbd.io/v1beta1
+kind: PlatformConfig
+metadata:
+  name: platformconfig-sample
+spec:
+`
+
+// Sample returns the sample manifest for this custom resource.
+func Sample(requiredOnly bool) string {
+	if requiredOnly {
+		return samplePlatformConfigRequired
+	}
+
+	return samplePlatformConfig
+}
+
+// Generate returns the child resources that are associated with this workload given
+// appropriate structured inputs.
+func Generate(
+	workloadObj deployv1beta1.PlatformConfig,
+	reconciler workload.Reconciler,
+	req *workload.Request,
+) ([]client.Object, error) {
+	resourceObjects := []client.Object{}
+
+	// the mutate hooks record the result of applying each override as the child resources are rendered
+	workloadObj.Status.Overrides = mutate.Statuses(workloadObj.Spec.Overrides)
+
+	for _, f := range CreateFuncs {
+		resources, err := f(&workloadObj, reconciler, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		resourceObjects = append(resourceObjects, resources...)
+	}
+
+	// a pod security level which the capability components would violate is not enforced, since their
+	// pods would no longer be admitted, and is reported upon the workload instead.  The components are
+	// deployed by the capability operators rather than generated here, so they are validated as they
+	// exist on the cluster.
+	violations := []deployv1beta1.PodSecurityViolation{}
+
+	for _, namespace := range []struct {
+		enabled bool
+		name    string
+		level   string
+	}{
+		{
+			enabled: workloadObj.Spec.Certificates.Enabled,
+			name:    workloadObj.Spec.Certificates.Namespace,
+			level:   workloadObj.Spec.Certificates.PodSecurity.Enforce,
+		},
+		{
+			enabled: workloadObj.Spec.Identity.Enabled,
+			name:    workloadObj.Spec.Identity.Namespace,
+			level:   workloadObj.Spec.Identity.PodSecurity.Enforce,
+		},
+	} {
+		if !namespace.enabled {
+			continue
+		}
+
+		err := podsecurity.ValidateDeployments(reconciler, req, namespace.name, namespace.level, disruption.IsCapabilityDeployment)
+		if err == nil {
+			continue
+		}
+
+		if !errors.Is(err, podsecurity.ErrLevelViolated) {
+			return nil, err
+		}
+
+		if err := podsecurity.Withhold(resourceObjects, namespace.name); err != nil {
+			return nil, err
+		}
+
+		violations = append(violations, deployv1beta1.PodSecurityViolation{
+			Namespace: namespace.name,
+			Level:     namespace.level,
+			Message:   err.Error(),
+		})
+	}
+
+	// apply the common labels and annotations, which may not override those the platform relies upon
+	if err := metadata.Apply(
+		reconciler,
+		req,
+		resourceObjects,
+		workloadObj.Spec.CommonLabels,
+		workloadObj.Spec.CommonAnnotations,
+	); err != nil {
+		return nil, err
+	}
+
+	// the overrides are applied by the mutate hooks as the child resources are rendered, which
+	// record the result of each upon the workload
+	if req == nil {
+		if err := mutate.OverridesError(workloadObj.Status.Overrides); err != nil {
+			return nil, err
+		}
+	} else {
+		component, err := ConvertWorkload(req.Workload)
+		if err != nil {
+			return nil, err
+		}
+
+		component.SetOverrideStatuses(workloadObj.Status.Overrides)
+		component.SetPodSecurityViolations(violations)
+	}
+
+	return resourceObjects, nil
+}
+
+// GenerateForCLI returns the child resources that are associated with this workload given
+// appropriate YAML manifest files.
+func GenerateForCLI(workloadFile []byte) ([]client.Object, error) {
+	var workloadObj deployv1beta1.PlatformConfig
+	if err := yaml.Unmarshal(workloadFile, &workloadObj); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal yaml into workload, %w", err)
+	}
+
+	if err := workload.Validate(&workloadObj); err != nil {
+		return nil, fmt.Errorf("error validating workload yaml, %w", err)
+	}
+
+	return Generate(workloadObj, nil, nil)
+}
+
+// CreateFuncs is an array of functions that are called to create the child resources for the controller
+// in memory during the reconciliation loop prior to persisting the changes or updates to the Kubernetes
+// database.
+var CreateFuncs = []func(
+	*deployv1beta1.PlatformConfig,
+	workload.Reconciler,
+	*workload.Request,
+) ([]client.Object, error){
+	CreateNamespacePlatformCertificatesNamespace,
+	CreateNamespacePlatformIdentityNamespace,
+	CreateNetworkPoliciesPlatformCertificatesNamespace,
+	CreateNetworkPoliciesPlatformIdentityNamespace,
+	CreateResourceBudgetPlatformCertificatesNamespace,
+	CreateResourceBudgetPlatformIdentityNamespace,
+	CreatePodDisruptionBudgetsPlatformCertificatesNamespace,
+	CreatePodDisruptionBudgetsPlatformIdentityNamespace,
+	CreateCertManagerConfig,
+	CreateClusterIssuerPlatformRootCA,
+	CreateClusterIssuersPlatformCertificates,
+	CreateTrustManagerConfig,
+	CreateBundlesPlatformCertificates,
+	CreateAWSPodIdentityWebhookConfig,
+	CreateConfigMapServiceAccountIssuerDiscovery,
+}
+
+// InitFuncs is an array of functions that are called prior to starting the controller manager.  This is
+// necessary in instances which the controller needs to "own" objects which depend on resources to
+// pre-exist in the cluster. A common use case for this is the need to own a custom resource.
+// If the controller needs to own a custom resource type, the CRD that defines it must
+// first exist. In this case, the InitFunc will create the CRD so that the controller
+// can own custom resources of that type.  Without the InitFunc the controller will
+// crash loop because when it tries to own a non-existent resource type during manager
+// setup, it will fail.
+var InitFuncs = []func(
+	*deployv1beta1.PlatformConfig,
+	workload.Reconciler,
+	*workload.Request,
+) ([]client.Object, error){}
+
+func ConvertWorkload(component workload.Workload) (*deployv1beta1.PlatformConfig, error) {
+	p, ok := component.(*deployv1beta1.PlatformConfig)
+	if !ok {
+		return nil, deployv1beta1.ErrUnableToConvertPlatformConfig
+	}
+
+	return p, nil
+}
@@ -0,0 +1,404 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"errors"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"github.com/nukleros/operator-builder-tools/pkg/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var ErrUnableToConvertPlatformConfig = errors.New("unable to convert to PlatformConfig")
+
+// PlatformConfigSpec defines the desired state of PlatformConfig.
+// +kubebuilder:validation:XValidation:rule="!self.cloud.local || self.identity.enabled || !has(self.certificates.issuers) || !self.certificates.issuers.exists(issuer, issuer.type == 'acmeDNS01Route53')",message="acmeDNS01Route53 issuers on a local cloud require the identity capability"
+type PlatformConfigSpec struct {
+	// Configuration for the certificates capability.
+	// +kubebuilder:default={}
+	// +kubebuilder:validation:Optional
+	Certificates PlatformConfigSpecCertificates `json:"certificates,omitempty"`
+
+	// Configuration for the identity capability.
+	// +kubebuilder:default={}
+	// +kubebuilder:validation:Optional
+	Identity PlatformConfigSpecIdentity `json:"identity,omitempty"`
+
+	// Configuration for the underlying cloud which the platform is deployed upon.
+	// +kubebuilder:default={}
+	// +kubebuilder:validation:Optional
+	Cloud PlatformConfigSpecCloud `json:"cloud,omitempty"`
+
+	// Network isolation of the capability namespaces.
+	// +kubebuilder:default={}
+	// +kubebuilder:validation:Optional
+	NetworkPolicy NetworkPolicy `json:"networkPolicy,omitempty"`
+
+	// Labels which are applied to every object which is generated for the capabilities,
+	// including the pods of workloads.  Keys which are reserved for the platform may not be set.
+	// Common labels take precedence over those which are generated for an object, except for the
+	// labels of pods, and labels which are no longer common are removed from the objects.
+	// +kubebuilder:validation:XValidation:rule="self.all(key, !key.matches('^([^/]+[.])?tbd[.]io/') && !key.startsWith('operator-builder.nukleros.io/') && !key.startsWith('pod-security.kubernetes.io/') && key != 'app.kubernetes.io/managed-by')",message="reserved keys may not be set"
+	// +kubebuilder:validation:Optional
+	CommonLabels map[string]string `json:"commonLabels,omitempty"`
+
+	// Annotations which are applied to every object which is generated for the capabilities,
+	// including the pods of workloads.  Keys which are reserved for the platform may not be set.
+	// Common annotations take precedence over those which are generated for an object, and
+	// annotations which are no longer common are removed from the objects.
+	// +kubebuilder:validation:XValidation:rule="self.all(key, !key.matches('^([^/]+[.])?tbd[.]io/') && !key.startsWith('operator-builder.nukleros.io/') && !key.startsWith('pod-security.kubernetes.io/') && key != 'app.kubernetes.io/managed-by')",message="reserved keys may not be set"
+	// +kubebuilder:validation:Optional
+	CommonAnnotations map[string]string `json:"commonAnnotations,omitempty"`
+
+	// HTTP(S) proxy which the capability components use to reach external services.
+	// +kubebuilder:validation:Optional
+	Proxy Proxy `json:"proxy,omitempty"`
+
+	// Additional environment variables which are injected into the capability components.
+	// +kubebuilder:validation:Optional
+	ExtraEnv []EnvVar `json:"extraEnv,omitempty"`
+
+	// Patches which are applied to the child resources which are generated for the capabilities,
+	// as an escape hatch for settings which are not otherwise modeled.  The result of applying each
+	// patch is reported in the status.
+	// +kubebuilder:validation:Optional
+	Overrides []Override `json:"overrides,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="!has(self.issuers) || !self.issuers.exists(issuer, issuer.type == 'acmeDNS01Route53') || (has(self.aws) && has(self.aws.roleARN) && self.aws.roleARN.size() > 0)",message="acmeDNS01Route53 issuers require aws.roleARN"
+type PlatformConfigSpecCertificates struct {
+	// Whether the certificates capability is deployed.  Disabling a capability removes any of its
+	// components which were previously deployed.
+	// +kubebuilder:default=true
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled"`
+
+	// Namespace where the certificates capability components will be deployed.
+	// +kubebuilder:default="tbd-certificates-system"
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Size of the deployment for the certificates capability.
+	// +kubebuilder:default="small"
+	// +kubebuilder:validation:Enum=small;medium;large
+	// +kubebuilder:validation:Optional
+	DeploymentSize string `json:"deploymentSize,omitempty"`
+
+	// Pod Security Admission levels of the certificates namespace.
+	// +kubebuilder:default={}
+	// +kubebuilder:validation:Optional
+	PodSecurity PodSecurity `json:"podSecurity,omitempty"`
+
+	// Overrides to the resource budget of the certificates namespace, which is otherwise derived from
+	// the deployment size.
+	// +kubebuilder:validation:Optional
+	ResourceBudget ResourceBudget `json:"resourceBudget,omitempty"`
+
+	// AWS integration of the certificates capability.
+	// +kubebuilder:default={}
+	// +kubebuilder:validation:Optional
+	AWS PlatformConfigSpecCertificatesAWS `json:"aws,omitempty"`
+
+	// ClusterIssuers which are created once the certificates capability is ready.  The readiness of
+	// each issuer is reported in the status.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:XValidation:rule="self.all(issuer, issuer.name != 'platform-root-ca')",message="the platform-root-ca issuer is reserved for the private root CA"
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:Optional
+	Issuers []Issuer `json:"issuers,omitempty"`
+
+	// Private root CA which is generated and rotated by the operator when the cloud is local.  The
+	// root CA is stored in the platform-root-ca Secret in the certificates namespace and exposed
+	// through the platform-root-ca ClusterIssuer.
+	// +kubebuilder:default={}
+	// +kubebuilder:validation:Optional
+	RootCA RootCA `json:"rootCA,omitempty"`
+
+	// Trust bundles which are distributed to namespaces by trust-manager.
+	// +kubebuilder:default={}
+	// +kubebuilder:validation:Optional
+	Trust Trust `json:"trust,omitempty"`
+}
+
+type PlatformConfigSpecCertificatesAWS struct {
+	// ARN of the IAM role which is assumed by cert-manager, e.g. to solve DNS01 challenges with Route53.
+	// The service account of cert-manager is annotated with the role, whose web identity token is
+	// injected by the pod identity webhook, which is deployed by the identity capability on a local
+	// cloud and provided by EKS otherwise.  ClusterIssuers use these ambient credentials, which
+	// cert-manager permits for ClusterIssuers by default.
+	// +kubebuilder:validation:Optional
+	RoleARN string `json:"roleARN,omitempty"`
+}
+
+type PlatformConfigSpecIdentity struct {
+	// Whether the identity capability is deployed.  Disabling a capability removes any of its
+	// components which were previously deployed.
+	// +kubebuilder:default=true
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled"`
+
+	// Namespace where the identity capability components will be deployed.
+	// +kubebuilder:default="tbd-identity-system"
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Size of the deployment for the identity capability.
+	// +kubebuilder:default="small"
+	// +kubebuilder:validation:Enum=small;medium;large
+	// +kubebuilder:validation:Optional
+	DeploymentSize string `json:"deploymentSize,omitempty"`
+
+	// Pod Security Admission levels of the identity namespace.
+	// +kubebuilder:default={}
+	// +kubebuilder:validation:Optional
+	PodSecurity PodSecurity `json:"podSecurity,omitempty"`
+
+	// Overrides to the resource budget of the identity namespace, which is otherwise derived from
+	// the deployment size.
+	// +kubebuilder:validation:Optional
+	ResourceBudget ResourceBudget `json:"resourceBudget,omitempty"`
+
+	// Publication of the discovery documents of the service account issuer of a local cloud.
+	// +kubebuilder:default={}
+	// +kubebuilder:validation:Optional
+	ServiceAccountIssuerDiscovery ServiceAccountIssuerDiscovery `json:"serviceAccountIssuerDiscovery,omitempty"`
+
+	// IAM roles which are assumed by service accounts, which are annotated accordingly by the operator.
+	// Annotations which were not set by the operator are never overwritten, and are instead reported
+	// as conflicts.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=64
+	// +kubebuilder:validation:Optional
+	RoleBindings []IdentityRoleBinding `json:"roleBindings,omitempty"`
+}
+
+type PlatformConfigSpecCloud struct {
+	// Underlying cloud type this platform is deployed upon.  Currently, only AWS is supported.
+	// +kubebuilder:default="aws"
+	// +kubebuilder:validation:Enum=aws
+	// +kubebuilder:validation:Optional
+	Type string `json:"type,omitempty"`
+
+	// Whether this cloud is deployed as a local cloud to use for testing scenarios.
+	// +kubebuilder:default=true
+	// +kubebuilder:validation:Optional
+	Local bool `json:"local"`
+
+	// Settings of the AWS account which this platform is deployed within.
+	// +kubebuilder:default={}
+	// +kubebuilder:validation:Optional
+	AWS PlatformConfigSpecCloudAWS `json:"aws,omitempty"`
+}
+
+type PlatformConfigSpecCloudAWS struct {
+	// ID of the AWS account, which is used to derive the ARNs of IAM roles which are named by the
+	// role bindings of the identity capability.
+	// +kubebuilder:validation:Pattern=`^[0-9]{12}$`
+	// +kubebuilder:validation:Optional
+	AccountID string `json:"accountID,omitempty"`
+}
+
+// PlatformConfigStatus defines the observed state of PlatformConfig.
+type PlatformConfigStatus struct {
+	Created               bool                     `json:"created,omitempty"`
+	DependenciesSatisfied bool                     `json:"dependenciesSatisfied,omitempty"`
+	Conditions            []*status.PhaseCondition `json:"conditions,omitempty"`
+	Resources             []*status.ChildResource  `json:"resources,omitempty"`
+
+	// Pod security levels which are not enforced, because the capability components violate them.
+	PodSecurityViolations []PodSecurityViolation `json:"podSecurityViolations,omitempty"`
+
+	// Version of the platform release which last reconciled the child resources.
+	PlatformVersion string `json:"platformVersion,omitempty"`
+
+	// Results of applying the overrides to the child resources.
+	Overrides []OverrideStatus `json:"overrides,omitempty"`
+
+	// Readiness of the ClusterIssuers of the certificates capability.
+	Issuers []IssuerStatus `json:"issuers,omitempty"`
+
+	// State of the private root CA, when the cloud is local.
+	RootCA *RootCAStatus `json:"rootCA,omitempty"`
+
+	// Namespaces which currently receive the trust bundles that do not select namespaces themselves.
+	InjectedNamespaces []string `json:"injectedNamespaces,omitempty"`
+
+	// State of the publication of the service account issuer discovery documents, when the cloud is
+	// local.
+	ServiceAccountIssuerDiscovery *ServiceAccountIssuerDiscoveryStatus `json:"serviceAccountIssuerDiscovery,omitempty"`
+
+	// State of the IAM role bindings of the identity capability.
+	RoleBindings []IdentityRoleBindingStatus `json:"roleBindings,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:storageversion
+
+// PlatformConfig is the Schema for the platformconfigs API.
+type PlatformConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:default={}
+	Spec   PlatformConfigSpec   `json:"spec,omitempty"`
+	Status PlatformConfigStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PlatformConfigList contains a list of PlatformConfig.
+type PlatformConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PlatformConfig `json:"items"`
+}
+
+// interface methods
+
+// GetReadyStatus returns the ready status for a component.
+func (component *PlatformConfig) GetReadyStatus() bool {
+	return component.Status.Created
+}
+
+// SetReadyStatus sets the ready status for a component.
+func (component *PlatformConfig) SetReadyStatus(ready bool) {
+	component.Status.Created = ready
+}
+
+// GetDependencyStatus returns the dependency status for a component.
+func (component *PlatformConfig) GetDependencyStatus() bool {
+	return component.Status.DependenciesSatisfied
+}
+
+// SetDependencyStatus sets the dependency status for a component.
+func (component *PlatformConfig) SetDependencyStatus(dependencyStatus bool) {
+	component.Status.DependenciesSatisfied = dependencyStatus
+}
+
+// GetPhaseConditions returns the phase conditions for a component.
+func (component *PlatformConfig) GetPhaseConditions() []*status.PhaseCondition {
+	return component.Status.Conditions
+}
+
+// SetPhaseCondition sets the phase conditions for a component.
+func (component *PlatformConfig) SetPhaseCondition(condition *status.PhaseCondition) {
+	for i, currentCondition := range component.GetPhaseConditions() {
+		if currentCondition.Phase == condition.Phase {
+			component.Status.Conditions[i] = condition
+
+			return
+		}
+	}
+
+	// phase not found, lets add it to the list.
+	component.Status.Conditions = append(component.Status.Conditions, condition)
+}
+
+// GetResources returns the child resource status for a component.
+func (component *PlatformConfig) GetChildResourceConditions() []*status.ChildResource {
+	return component.Status.Resources
+}
+
+// SetResources sets the phase conditions for a component.
+func (component *PlatformConfig) SetChildResourceCondition(resource *status.ChildResource) {
+	for i, currentResource := range component.GetChildResourceConditions() {
+		if currentResource.Group == resource.Group && currentResource.Version == resource.Version && currentResource.Kind == resource.Kind {
+			if currentResource.Name == resource.Name && currentResource.Namespace == resource.Namespace {
+				component.Status.Resources[i] = resource
+
+				return
+			}
+		}
+	}
+
+	// phase not found, lets add it to the collection
+	component.Status.Resources = append(component.Status.Resources, resource)
+}
+
+// RemoveChildResourceCondition removes a child resource from the status of the component.
+func (component *PlatformConfig) RemoveChildResourceCondition(resource *status.ChildResource) {
+	for i, currentResource := range component.GetChildResourceConditions() {
+		if currentResource.Group == resource.Group && currentResource.Version == resource.Version && currentResource.Kind == resource.Kind {
+			if currentResource.Name == resource.Name && currentResource.Namespace == resource.Namespace {
+				component.Status.Resources = append(component.Status.Resources[:i], component.Status.Resources[i+1:]...)
+
+				return
+			}
+		}
+	}
+}
+
+// SetPodSecurityViolations sets the pod security levels which are not enforced.
+func (component *PlatformConfig) SetPodSecurityViolations(violations []PodSecurityViolation) {
+	component.Status.PodSecurityViolations = violations
+}
+
+// SetOverrideStatuses sets the results of applying the overrides to the child resources.
+func (component *PlatformConfig) SetOverrideStatuses(statuses []OverrideStatus) {
+	component.Status.Overrides = statuses
+}
+
+// SetIssuerStatuses sets the readiness of the ClusterIssuers of the certificates capability.
+func (component *PlatformConfig) SetIssuerStatuses(statuses []IssuerStatus) {
+	component.Status.Issuers = statuses
+}
+
+// SetRootCAStatus sets the state of the private root CA.
+func (component *PlatformConfig) SetRootCAStatus(rootCAStatus *RootCAStatus) {
+	component.Status.RootCA = rootCAStatus
+}
+
+// SetInjectedNamespaces sets the namespaces which receive the trust bundles.
+func (component *PlatformConfig) SetInjectedNamespaces(namespaces []string) {
+	component.Status.InjectedNamespaces = namespaces
+}
+
+// SetServiceAccountIssuerDiscoveryStatus sets the state of the publication of the service account
+// issuer discovery documents.
+func (component *PlatformConfig) SetServiceAccountIssuerDiscoveryStatus(discoveryStatus *ServiceAccountIssuerDiscoveryStatus) {
+	component.Status.ServiceAccountIssuerDiscovery = discoveryStatus
+}
+
+// SetRoleBindingStatuses sets the state of the IAM role bindings of the identity capability.
+func (component *PlatformConfig) SetRoleBindingStatuses(statuses []IdentityRoleBindingStatus) {
+	component.Status.RoleBindings = statuses
+}
+
+// SetPlatformVersion sets the version of the platform release which reconciled the component.
+func (component *PlatformConfig) SetPlatformVersion(version string) {
+	component.Status.PlatformVersion = version
+}
+
+// GetDependencies returns the dependencies for a component.
+func (*PlatformConfig) GetDependencies() []workload.Workload {
+	return []workload.Workload{}
+}
+
+// GetComponentGVK returns a GVK object for the component.
+func (*PlatformConfig) GetWorkloadGVK() schema.GroupVersionKind {
+	return GroupVersion.WithKind("PlatformConfig")
+}
+
+func init() {
+	SchemeBuilder.Register(&PlatformConfig{}, &PlatformConfigList{})
+}
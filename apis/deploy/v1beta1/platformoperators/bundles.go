@@ -0,0 +1,153 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platformoperators
+
+import (
+	"errors"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+)
+
+const (
+	// DefaultCertificatesVersion is the version of the certificates operator which is deployed when
+	// no version is requested.
+	DefaultCertificatesVersion = "v0.0.0-alpha.2"
+
+	// DefaultIdentityVersion is the version of the identity operator which is deployed when no
+	// version is requested.
+	DefaultIdentityVersion = "v0.0.0-alpha.2"
+)
+
+var ErrUnknownBundleVersion = errors.New("no bundle is embedded for requested version")
+
+// Bundle is the collection of functions which create the child resources for a specific version
+// of a capability operator.
+type Bundle []func(
+	*deployv1beta1.PlatformOperators,
+	workload.Reconciler,
+	*workload.Request,
+) ([]client.Object, error)
+
+// CertificatesBundles are the bundles of the certificates operator which are embedded in this
+// operator, keyed by version.  The versions must match the enum of the certificates operator
+// version in the schema of the PlatformOperators custom resource.
+var CertificatesBundles = map[string]Bundle{
+	"v0.0.0-alpha.2": {
+		CreateCRDCertmanagersCertificatesPlatformTbdIo,
+		CreateCRDTrustmanagersCertificatesPlatformTbdIo,
+		CreateServiceAccountNamespaceCertificatesOperatorControllerManager,
+		CreateRoleNamespaceCertificatesOperatorLeaderElectionRole,
+		CreateClusterRoleCertificatesOperatorCertificatesCertmanagerEditorRole,
+		CreateClusterRoleCertificatesOperatorCertificatesCertmanagerViewerRole,
+		CreateClusterRoleCertificatesOperatorCertificatesTrustmanagerEditorRole,
+		CreateClusterRoleCertificatesOperatorCertificatesTrustmanagerViewerRole,
+		CreateClusterRoleCertificatesOperatorManagerRole,
+		CreateRoleBindingNamespaceCertificatesOperatorLeaderElectionRolebinding,
+		CreateClusterRoleBindingCertificatesOperatorManagerRolebinding,
+		CreateDeploymentNamespaceCertificatesOperatorControllerManager,
+		CreatePodDisruptionBudgetNamespaceCertificatesOperatorControllerManager,
+	},
+}
+
+// IdentityBundles are the bundles of the identity operator which are embedded in this operator,
+// keyed by version.  The versions must match the enum of the identity operator version in the
+// schema of the PlatformOperators custom resource.
+var IdentityBundles = map[string]Bundle{
+	"v0.0.0-alpha.2": {
+		CreateCRDAwspodidentitywebhooksIdentityPlatformTbdIo,
+		CreateServiceAccountNamespaceIdentityOperatorControllerManager,
+		CreateRoleNamespaceIdentityOperatorLeaderElectionRole,
+		CreateClusterRoleIdentityOperatorIdentityAwspodidentitywebhookEditorRole,
+		CreateClusterRoleIdentityOperatorIdentityAwspodidentitywebhookViewerRole,
+		CreateClusterRoleIdentityOperatorManagerRole,
+		CreateRoleBindingNamespaceIdentityOperatorLeaderElectionRolebinding,
+		CreateClusterRoleBindingIdentityOperatorManagerRolebinding,
+		CreateDeploymentNamespaceIdentityOperatorControllerManager,
+		CreatePodDisruptionBudgetNamespaceIdentityOperatorControllerManager,
+	},
+}
+
+// CreateCertificatesOperator creates the resources of the certificates operator bundle for the
+// requested version.
+func CreateCertificatesOperator(
+	parent *deployv1beta1.PlatformOperators,
+	reconciler workload.Reconciler,
+	req *workload.Request,
+) ([]client.Object, error) {
+	if parent.Spec.Operators.Certificates.Enabled != true {
+		return []client.Object{}, nil
+	}
+
+	version := parent.Spec.Operators.Certificates.Version
+	if version == "" {
+		version = DefaultCertificatesVersion
+	}
+
+	return createBundle("certificates", CertificatesBundles, version, parent, reconciler, req)
+}
+
+// CreateIdentityOperator creates the resources of the identity operator bundle for the requested
+// version.
+func CreateIdentityOperator(
+	parent *deployv1beta1.PlatformOperators,
+	reconciler workload.Reconciler,
+	req *workload.Request,
+) ([]client.Object, error) {
+	if parent.Spec.Operators.Identity.Enabled != true {
+		return []client.Object{}, nil
+	}
+
+	version := parent.Spec.Operators.Identity.Version
+	if version == "" {
+		version = DefaultIdentityVersion
+	}
+
+	return createBundle("identity", IdentityBundles, version, parent, reconciler, req)
+}
+
+// createBundle creates the resources of the bundle for a particular version.
+func createBundle(
+	name string,
+	bundles map[string]Bundle,
+	version string,
+	parent *deployv1beta1.PlatformOperators,
+	reconciler workload.Reconciler,
+	req *workload.Request,
+) ([]client.Object, error) {
+	bundle, ok := bundles[version]
+	if !ok {
+		return nil, fmt.Errorf("%w %s for %s operator", ErrUnknownBundleVersion, version, name)
+	}
+
+	resourceObjects := []client.Object{}
+
+	for _, f := range bundle {
+		resources, err := f(parent, reconciler, req)
+		if err != nil {
+			return nil, err
+		}
+
+		resourceObjects = append(resourceObjects, resources...)
+	}
+
+	return resourceObjects, nil
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platformoperators
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+	"github.com/tbd-paas/platform-config-operator/internal/crdschema"
+)
+
+// TestBundleVersionsMatchSchema ensures that the versions which the schema accepts are exactly
+// the versions of the bundles which are embedded in this operator.
+func TestBundleVersionsMatchSchema(t *testing.T) {
+	t.Parallel()
+
+	structural, err := crdschema.Schema(deployv1beta1.GroupVersion.WithKind("PlatformOperators"))
+	require.NoError(t, err)
+
+	operators := structural.Properties["spec"].Properties["operators"]
+
+	for name, tc := range map[string]struct {
+		bundles        map[string]Bundle
+		defaultVersion string
+	}{
+		"certificates": {bundles: CertificatesBundles, defaultVersion: DefaultCertificatesVersion},
+		"identity":     {bundles: IdentityBundles, defaultVersion: DefaultIdentityVersion},
+	} {
+		version := operators.Properties[name].Properties["version"]
+		require.NotNil(t, version.ValueValidation, name)
+
+		expected := []string{}
+		for bundleVersion := range tc.bundles {
+			expected = append(expected, bundleVersion)
+		}
+
+		actual := []string{}
+		for _, enum := range version.ValueValidation.Enum {
+			actual = append(actual, enum.Object.(string))
+		}
+
+		sort.Strings(expected)
+		sort.Strings(actual)
+
+		require.Equal(t, expected, actual, "+kubebuilder:validation:Enum of the %s operator version", name)
+		require.Equal(t, tc.defaultVersion, version.Default.Object, "+kubebuilder:default of the %s operator version", name)
+		require.Contains(t, tc.bundles, tc.defaultVersion, name)
+	}
+}
@@ -22,15 +22,17 @@ import (
 
 	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
 
-	deployv1alpha1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1alpha1"
-	"github.com/tbd-paas/platform-config-operator/apis/deploy/v1alpha1/platformoperators/mutate"
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+	"github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1/platformoperators/mutate"
+	"github.com/tbd-paas/platform-config-operator/internal/proxy"
+	"github.com/tbd-paas/platform-config-operator/internal/version"
 )
 
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch;create;update;patch;delete
 
 // CreateCRDCertmanagersCertificatesPlatformTbdIo creates the CustomResourceDefinition resource with name certmanagers.certificates.platform.tbd.io.
 func CreateCRDCertmanagersCertificatesPlatformTbdIo(
-	parent *deployv1alpha1.PlatformOperators,
+	parent *deployv1beta1.PlatformOperators,
 	reconciler workload.Reconciler,
 	req *workload.Request,
 ) ([]client.Object, error) {
@@ -50,10 +52,10 @@ func CreateCRDCertmanagersCertificatesPlatformTbdIo(
 					"app.kubernetes.io/managed-by":         "platform-config-operator",
 					"app.kubernetes.io/name":               "certificates-operator",
 					"app.kubernetes.io/part-of":            "platform",
-					"app.kubernetes.io/version":            "unstable",
+					"app.kubernetes.io/version":            version.Version,
 					"capabilities.tbd.io/capability":       "platform-config",
-					"capabilities.tbd.io/platform-version": "unstable",
-					"capabilities.tbd.io/version":          "v0.0.1",
+					"capabilities.tbd.io/platform-version": version.Version,
+					"capabilities.tbd.io/version":          version.CapabilityVersion,
 				},
 				"name": "certmanagers.certificates.platform.tbd.io",
 			},
@@ -424,7 +426,7 @@ of the controller reconciliation loop.`,
 
 // CreateCRDTrustmanagersCertificatesPlatformTbdIo creates the CustomResourceDefinition resource with name trustmanagers.certificates.platform.tbd.io.
 func CreateCRDTrustmanagersCertificatesPlatformTbdIo(
-	parent *deployv1alpha1.PlatformOperators,
+	parent *deployv1beta1.PlatformOperators,
 	reconciler workload.Reconciler,
 	req *workload.Request,
 ) ([]client.Object, error) {
@@ -444,10 +446,10 @@ func CreateCRDTrustmanagersCertificatesPlatformTbdIo(
 					"app.kubernetes.io/managed-by":         "platform-config-operator",
 					"app.kubernetes.io/name":               "certificates-operator",
 					"app.kubernetes.io/part-of":            "platform",
-					"app.kubernetes.io/version":            "unstable",
+					"app.kubernetes.io/version":            version.Version,
 					"capabilities.tbd.io/capability":       "platform-config",
-					"capabilities.tbd.io/platform-version": "unstable",
-					"capabilities.tbd.io/version":          "v0.0.1",
+					"capabilities.tbd.io/platform-version": version.Version,
+					"capabilities.tbd.io/version":          version.CapabilityVersion,
 				},
 				"name": "trustmanagers.certificates.platform.tbd.io",
 			},
@@ -685,7 +687,7 @@ of the controller reconciliation loop.`,
 
 // CreateServiceAccountNamespaceCertificatesOperatorControllerManager creates the ServiceAccount resource with name certificates-operator-controller-manager.
 func CreateServiceAccountNamespaceCertificatesOperatorControllerManager(
-	parent *deployv1alpha1.PlatformOperators,
+	parent *deployv1beta1.PlatformOperators,
 	reconciler workload.Reconciler,
 	req *workload.Request,
 ) ([]client.Object, error) {
@@ -702,10 +704,10 @@ func CreateServiceAccountNamespaceCertificatesOperatorControllerManager(
 					"app.kubernetes.io/managed-by":         "platform-config-operator",
 					"app.kubernetes.io/name":               "certificates-operator",
 					"app.kubernetes.io/part-of":            "platform",
-					"app.kubernetes.io/version":            "unstable",
+					"app.kubernetes.io/version":            version.Version,
 					"capabilities.tbd.io/capability":       "platform-config",
-					"capabilities.tbd.io/platform-version": "unstable",
-					"capabilities.tbd.io/version":          "v0.0.1",
+					"capabilities.tbd.io/platform-version": version.Version,
+					"capabilities.tbd.io/version":          version.CapabilityVersion,
 				},
 				"name":      "certificates-operator-controller-manager",
 				"namespace": parent.Spec.Namespace, //  controlled by field: namespace
@@ -723,7 +725,7 @@ func CreateServiceAccountNamespaceCertificatesOperatorControllerManager(
 
 // CreateRoleNamespaceCertificatesOperatorLeaderElectionRole creates the Role resource with name certificates-operator-leader-election-role.
 func CreateRoleNamespaceCertificatesOperatorLeaderElectionRole(
-	parent *deployv1alpha1.PlatformOperators,
+	parent *deployv1beta1.PlatformOperators,
 	reconciler workload.Reconciler,
 	req *workload.Request,
 ) ([]client.Object, error) {
@@ -740,10 +742,10 @@ func CreateRoleNamespaceCertificatesOperatorLeaderElectionRole(
 					"app.kubernetes.io/managed-by":         "platform-config-operator",
 					"app.kubernetes.io/name":               "certificates-operator",
 					"app.kubernetes.io/part-of":            "platform",
-					"app.kubernetes.io/version":            "unstable",
+					"app.kubernetes.io/version":            version.Version,
 					"capabilities.tbd.io/capability":       "platform-config",
-					"capabilities.tbd.io/platform-version": "unstable",
-					"capabilities.tbd.io/version":          "v0.0.1",
+					"capabilities.tbd.io/platform-version": version.Version,
+					"capabilities.tbd.io/version":          version.CapabilityVersion,
 				},
 				"name":      "certificates-operator-leader-election-role",
 				"namespace": parent.Spec.Namespace, //  controlled by field: namespace
@@ -808,7 +810,7 @@ func CreateRoleNamespaceCertificatesOperatorLeaderElectionRole(
 
 // CreateClusterRoleCertificatesOperatorCertificatesCertmanagerEditorRole creates the ClusterRole resource with name certificates-operator-certificates-certmanager-editor-role.
 func CreateClusterRoleCertificatesOperatorCertificatesCertmanagerEditorRole(
-	parent *deployv1alpha1.PlatformOperators,
+	parent *deployv1beta1.PlatformOperators,
 	reconciler workload.Reconciler,
 	req *workload.Request,
 ) ([]client.Object, error) {
@@ -825,10 +827,10 @@ func CreateClusterRoleCertificatesOperatorCertificatesCertmanagerEditorRole(
 					"app.kubernetes.io/managed-by":         "platform-config-operator",
 					"app.kubernetes.io/name":               "certificates-operator",
 					"app.kubernetes.io/part-of":            "platform",
-					"app.kubernetes.io/version":            "unstable",
+					"app.kubernetes.io/version":            version.Version,
 					"capabilities.tbd.io/capability":       "platform-config",
-					"capabilities.tbd.io/platform-version": "unstable",
-					"capabilities.tbd.io/version":          "v0.0.1",
+					"capabilities.tbd.io/platform-version": version.Version,
+					"capabilities.tbd.io/version":          version.CapabilityVersion,
 				},
 				"name": "certificates-operator-certificates-certmanager-editor-role",
 			},
@@ -874,7 +876,7 @@ func CreateClusterRoleCertificatesOperatorCertificatesCertmanagerEditorRole(
 
 // CreateClusterRoleCertificatesOperatorCertificatesCertmanagerViewerRole creates the ClusterRole resource with name certificates-operator-certificates-certmanager-viewer-role.
 func CreateClusterRoleCertificatesOperatorCertificatesCertmanagerViewerRole(
-	parent *deployv1alpha1.PlatformOperators,
+	parent *deployv1beta1.PlatformOperators,
 	reconciler workload.Reconciler,
 	req *workload.Request,
 ) ([]client.Object, error) {
@@ -891,10 +893,10 @@ func CreateClusterRoleCertificatesOperatorCertificatesCertmanagerViewerRole(
 					"app.kubernetes.io/managed-by":         "platform-config-operator",
 					"app.kubernetes.io/name":               "certificates-operator",
 					"app.kubernetes.io/part-of":            "platform",
-					"app.kubernetes.io/version":            "unstable",
+					"app.kubernetes.io/version":            version.Version,
 					"capabilities.tbd.io/capability":       "platform-config",
-					"capabilities.tbd.io/platform-version": "unstable",
-					"capabilities.tbd.io/version":          "v0.0.1",
+					"capabilities.tbd.io/platform-version": version.Version,
+					"capabilities.tbd.io/version":          version.CapabilityVersion,
 				},
 				"name": "certificates-operator-certificates-certmanager-viewer-role",
 			},
@@ -936,7 +938,7 @@ func CreateClusterRoleCertificatesOperatorCertificatesCertmanagerViewerRole(
 
 // CreateClusterRoleCertificatesOperatorCertificatesTrustmanagerEditorRole creates the ClusterRole resource with name certificates-operator-certificates-trustmanager-editor-role.
 func CreateClusterRoleCertificatesOperatorCertificatesTrustmanagerEditorRole(
-	parent *deployv1alpha1.PlatformOperators,
+	parent *deployv1beta1.PlatformOperators,
 	reconciler workload.Reconciler,
 	req *workload.Request,
 ) ([]client.Object, error) {
@@ -953,10 +955,10 @@ func CreateClusterRoleCertificatesOperatorCertificatesTrustmanagerEditorRole(
 					"app.kubernetes.io/managed-by":         "platform-config-operator",
 					"app.kubernetes.io/name":               "certificates-operator",
 					"app.kubernetes.io/part-of":            "platform",
-					"app.kubernetes.io/version":            "unstable",
+					"app.kubernetes.io/version":            version.Version,
 					"capabilities.tbd.io/capability":       "platform-config",
-					"capabilities.tbd.io/platform-version": "unstable",
-					"capabilities.tbd.io/version":          "v0.0.1",
+					"capabilities.tbd.io/platform-version": version.Version,
+					"capabilities.tbd.io/version":          version.CapabilityVersion,
 				},
 				"name": "certificates-operator-certificates-trustmanager-editor-role",
 			},
@@ -1002,7 +1004,7 @@ func CreateClusterRoleCertificatesOperatorCertificatesTrustmanagerEditorRole(
 
 // CreateClusterRoleCertificatesOperatorCertificatesTrustmanagerViewerRole creates the ClusterRole resource with name certificates-operator-certificates-trustmanager-viewer-role.
 func CreateClusterRoleCertificatesOperatorCertificatesTrustmanagerViewerRole(
-	parent *deployv1alpha1.PlatformOperators,
+	parent *deployv1beta1.PlatformOperators,
 	reconciler workload.Reconciler,
 	req *workload.Request,
 ) ([]client.Object, error) {
@@ -1019,10 +1021,10 @@ func CreateClusterRoleCertificatesOperatorCertificatesTrustmanagerViewerRole(
 					"app.kubernetes.io/managed-by":         "platform-config-operator",
 					"app.kubernetes.io/name":               "certificates-operator",
 					"app.kubernetes.io/part-of":            "platform",
-					"app.kubernetes.io/version":            "unstable",
+					"app.kubernetes.io/version":            version.Version,
 					"capabilities.tbd.io/capability":       "platform-config",
-					"capabilities.tbd.io/platform-version": "unstable",
-					"capabilities.tbd.io/version":          "v0.0.1",
+					"capabilities.tbd.io/platform-version": version.Version,
+					"capabilities.tbd.io/version":          version.CapabilityVersion,
 				},
 				"name": "certificates-operator-certificates-trustmanager-viewer-role",
 			},
@@ -1114,7 +1116,7 @@ func CreateClusterRoleCertificatesOperatorCertificatesTrustmanagerViewerRole(
 
 // CreateClusterRoleCertificatesOperatorManagerRole creates the ClusterRole resource with name certificates-operator-manager-role.
 func CreateClusterRoleCertificatesOperatorManagerRole(
-	parent *deployv1alpha1.PlatformOperators,
+	parent *deployv1beta1.PlatformOperators,
 	reconciler workload.Reconciler,
 	req *workload.Request,
 ) ([]client.Object, error) {
@@ -1131,10 +1133,10 @@ func CreateClusterRoleCertificatesOperatorManagerRole(
 					"app.kubernetes.io/managed-by":         "platform-config-operator",
 					"app.kubernetes.io/name":               "certificates-operator",
 					"app.kubernetes.io/part-of":            "platform",
-					"app.kubernetes.io/version":            "unstable",
+					"app.kubernetes.io/version":            version.Version,
 					"capabilities.tbd.io/capability":       "platform-config",
-					"capabilities.tbd.io/platform-version": "unstable",
-					"capabilities.tbd.io/version":          "v0.0.1",
+					"capabilities.tbd.io/platform-version": version.Version,
+					"capabilities.tbd.io/version":          version.CapabilityVersion,
 				},
 				"name": "certificates-operator-manager-role",
 			},
@@ -1907,7 +1909,7 @@ func CreateClusterRoleCertificatesOperatorManagerRole(
 
 // CreateRoleBindingNamespaceCertificatesOperatorLeaderElectionRolebinding creates the RoleBinding resource with name certificates-operator-leader-election-rolebinding.
 func CreateRoleBindingNamespaceCertificatesOperatorLeaderElectionRolebinding(
-	parent *deployv1alpha1.PlatformOperators,
+	parent *deployv1beta1.PlatformOperators,
 	reconciler workload.Reconciler,
 	req *workload.Request,
 ) ([]client.Object, error) {
@@ -1924,10 +1926,10 @@ func CreateRoleBindingNamespaceCertificatesOperatorLeaderElectionRolebinding(
 					"app.kubernetes.io/managed-by":         "platform-config-operator",
 					"app.kubernetes.io/name":               "certificates-operator",
 					"app.kubernetes.io/part-of":            "platform",
-					"app.kubernetes.io/version":            "unstable",
+					"app.kubernetes.io/version":            version.Version,
 					"capabilities.tbd.io/capability":       "platform-config",
-					"capabilities.tbd.io/platform-version": "unstable",
-					"capabilities.tbd.io/version":          "v0.0.1",
+					"capabilities.tbd.io/platform-version": version.Version,
+					"capabilities.tbd.io/version":          version.CapabilityVersion,
 				},
 				"name":      "certificates-operator-leader-election-rolebinding",
 				"namespace": parent.Spec.Namespace, //  controlled by field: namespace
@@ -1954,7 +1956,7 @@ func CreateRoleBindingNamespaceCertificatesOperatorLeaderElectionRolebinding(
 
 // CreateClusterRoleBindingCertificatesOperatorManagerRolebinding creates the ClusterRoleBinding resource with name certificates-operator-manager-rolebinding.
 func CreateClusterRoleBindingCertificatesOperatorManagerRolebinding(
-	parent *deployv1alpha1.PlatformOperators,
+	parent *deployv1beta1.PlatformOperators,
 	reconciler workload.Reconciler,
 	req *workload.Request,
 ) ([]client.Object, error) {
@@ -1971,10 +1973,10 @@ func CreateClusterRoleBindingCertificatesOperatorManagerRolebinding(
 					"app.kubernetes.io/managed-by":         "platform-config-operator",
 					"app.kubernetes.io/name":               "certificates-operator",
 					"app.kubernetes.io/part-of":            "platform",
-					"app.kubernetes.io/version":            "unstable",
+					"app.kubernetes.io/version":            version.Version,
 					"capabilities.tbd.io/capability":       "platform-config",
-					"capabilities.tbd.io/platform-version": "unstable",
-					"capabilities.tbd.io/version":          "v0.0.1",
+					"capabilities.tbd.io/platform-version": version.Version,
+					"capabilities.tbd.io/version":          version.CapabilityVersion,
 				},
 				"name": "certificates-operator-manager-rolebinding",
 			},
@@ -2000,11 +2002,18 @@ func CreateClusterRoleBindingCertificatesOperatorManagerRolebinding(
 
 // CreateDeploymentNamespaceCertificatesOperatorControllerManager creates the Deployment resource with name certificates-operator-controller-manager.
 func CreateDeploymentNamespaceCertificatesOperatorControllerManager(
-	parent *deployv1alpha1.PlatformOperators,
+	parent *deployv1beta1.PlatformOperators,
 	reconciler workload.Reconciler,
 	req *workload.Request,
 ) ([]client.Object, error) {
 
+	proxySpec, err := proxy.Resolve(reconciler, req, parent.Spec.Proxy)
+	if err != nil {
+		return nil, err
+	}
+
+	env := proxy.Env(proxySpec, parent.Spec.ExtraEnv)
+
 	var resourceObj = &unstructured.Unstructured{
 		Object: map[string]interface{}{
 			"apiVersion": "apps/v1",
@@ -2017,30 +2026,31 @@ func CreateDeploymentNamespaceCertificatesOperatorControllerManager(
 					"app.kubernetes.io/managed-by":         "platform-config-operator",
 					"app.kubernetes.io/name":               "certificates-operator",
 					"app.kubernetes.io/part-of":            "platform",
-					"app.kubernetes.io/version":            "unstable",
+					"app.kubernetes.io/version":            version.Version,
 					"capabilities.tbd.io/capability":       "platform-config",
-					"capabilities.tbd.io/platform-version": "unstable",
-					"capabilities.tbd.io/version":          "v0.0.1",
+					"capabilities.tbd.io/platform-version": version.Version,
+					"capabilities.tbd.io/version":          version.CapabilityVersion,
 					"control-plane":                        "controller-manager",
 				},
 				"name":      "certificates-operator-controller-manager",
 				"namespace": parent.Spec.Namespace, //  controlled by field: namespace
 			},
 			"spec": map[string]interface{}{
-				"replicas": 1,
+				// controlled by field: operators.certificates.replicas
+				//  Number of replicas to use for the operator for the certificates capability.
+				"replicas": parent.Spec.Operators.Certificates.Replicas,
+				// the selector of a deployment is immutable, so it omits the version labels, which change
+				// with every release and are only carried by the pod template
 				"selector": map[string]interface{}{
 					"matchLabels": map[string]interface{}{
-						"app":                                  "certificates-operator",
-						"app.kubernetes.io/component":          "certificates-operator",
-						"app.kubernetes.io/instance":           "manager",
-						"app.kubernetes.io/managed-by":         "platform-config-operator",
-						"app.kubernetes.io/name":               "certificates-operator",
-						"app.kubernetes.io/part-of":            "platform",
-						"app.kubernetes.io/version":            "unstable",
-						"capabilities.tbd.io/capability":       "certificates",
-						"capabilities.tbd.io/platform-version": "unstable",
-						"capabilities.tbd.io/version":          "v0.0.1",
-						"control-plane":                        "controller-manager",
+						"app":                            "certificates-operator",
+						"app.kubernetes.io/component":    "certificates-operator",
+						"app.kubernetes.io/instance":     "manager",
+						"app.kubernetes.io/managed-by":   "platform-config-operator",
+						"app.kubernetes.io/name":         "certificates-operator",
+						"app.kubernetes.io/part-of":      "platform",
+						"capabilities.tbd.io/capability": "certificates",
+						"control-plane":                  "controller-manager",
 					},
 				},
 				"template": map[string]interface{}{
@@ -2055,10 +2065,10 @@ func CreateDeploymentNamespaceCertificatesOperatorControllerManager(
 							"app.kubernetes.io/managed-by":         "platform-config-operator",
 							"app.kubernetes.io/name":               "certificates-operator",
 							"app.kubernetes.io/part-of":            "platform",
-							"app.kubernetes.io/version":            "unstable",
+							"app.kubernetes.io/version":            version.Version,
 							"capabilities.tbd.io/capability":       "certificates",
-							"capabilities.tbd.io/platform-version": "unstable",
-							"capabilities.tbd.io/version":          "v0.0.1",
+							"capabilities.tbd.io/platform-version": version.Version,
+							"capabilities.tbd.io/version":          version.CapabilityVersion,
 							"control-plane":                        "controller-manager",
 						},
 					},
@@ -2127,10 +2137,15 @@ func CreateDeploymentNamespaceCertificatesOperatorControllerManager(
 									"--health-probe-bind-address=:8081",
 									"--metrics-bind-address=127.0.0.1:8080",
 									"--leader-elect",
+									// controlled by field: operators.certificates.logLevel
+									//  Log level of the operator for the certificates capability.
+									"--zap-log-level=" + parent.Spec.Operators.Certificates.LogLevel,
 								},
 								"command": []interface{}{
 									"/manager",
 								},
+								// controlled by fields: proxy, extraEnv
+								"env":   env,
 								"image": "quay.io/tbd-paas/certificates-operator:v0.0.0-alpha.2",
 								"livenessProbe": map[string]interface{}{
 									"httpGet": map[string]interface{}{
@@ -2151,12 +2166,12 @@ func CreateDeploymentNamespaceCertificatesOperatorControllerManager(
 								},
 								"resources": map[string]interface{}{
 									"limits": map[string]interface{}{
-										"cpu":    "125m",
-										"memory": "64Mi",
+										"cpu":    parent.Spec.Operators.Certificates.Resources.Limits.CPU,    //  controlled by field: operators.certificates.resources.limits.cpu
+										"memory": parent.Spec.Operators.Certificates.Resources.Limits.Memory, //  controlled by field: operators.certificates.resources.limits.memory
 									},
 									"requests": map[string]interface{}{
-										"cpu":    "10m",
-										"memory": "16Mi",
+										"cpu":    parent.Spec.Operators.Certificates.Resources.Requests.CPU,    //  controlled by field: operators.certificates.resources.requests.cpu
+										"memory": parent.Spec.Operators.Certificates.Resources.Requests.Memory, //  controlled by field: operators.certificates.resources.requests.memory
 									},
 								},
 								"securityContext": map[string]interface{}{
@@ -2179,7 +2194,12 @@ func CreateDeploymentNamespaceCertificatesOperatorControllerManager(
 							"runAsGroup":   1001,
 							"runAsNonRoot": true,
 							"runAsUser":    1001,
+							"seccompProfile": map[string]interface{}{
+								"type": "RuntimeDefault",
+							},
 						},
+						// controlled by fields: priorityClassName, createPriorityClasses
+						"priorityClassName":             PriorityClassName(parent),
 						"serviceAccountName":            "certificates-operator-controller-manager",
 						"terminationGracePeriodSeconds": 10,
 					},
@@ -2190,3 +2210,58 @@ func CreateDeploymentNamespaceCertificatesOperatorControllerManager(
 
 	return mutate.MutateDeploymentNamespaceCertificatesOperatorControllerManager(resourceObj, parent, reconciler, req)
 }
+
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+
+// CreatePodDisruptionBudgetNamespaceCertificatesOperatorControllerManager creates the PodDisruptionBudget resource with name certificates-operator-controller-manager.
+func CreatePodDisruptionBudgetNamespaceCertificatesOperatorControllerManager(
+	parent *deployv1beta1.PlatformOperators,
+	reconciler workload.Reconciler,
+	req *workload.Request,
+) ([]client.Object, error) {
+
+	var resourceObj = &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "policy/v1",
+			"kind":       "PodDisruptionBudget",
+			"metadata": map[string]interface{}{
+				"labels": map[string]interface{}{
+					"app":                                  "certificates-operator",
+					"app.kubernetes.io/component":          "certificates-operator",
+					"app.kubernetes.io/instance":           "manager",
+					"app.kubernetes.io/managed-by":         "platform-config-operator",
+					"app.kubernetes.io/name":               "certificates-operator",
+					"app.kubernetes.io/part-of":            "platform",
+					"app.kubernetes.io/version":            version.Version,
+					"capabilities.tbd.io/capability":       "platform-config",
+					"capabilities.tbd.io/platform-version": version.Version,
+					"capabilities.tbd.io/version":          version.CapabilityVersion,
+					"control-plane":                        "controller-manager",
+				},
+				"name":      "certificates-operator-controller-manager",
+				"namespace": parent.Spec.Namespace, //  controlled by field: namespace
+			},
+			"spec": map[string]interface{}{
+				// the operator is leader elected, so a single replica may always be disrupted without
+				// blocking a drain, while additional replicas are kept available
+				"maxUnavailable":             1,
+				"unhealthyPodEvictionPolicy": "AlwaysAllow",
+				// the selector omits the version labels, matching the selector of the deployment
+				"selector": map[string]interface{}{
+					"matchLabels": map[string]interface{}{
+						"app":                            "certificates-operator",
+						"app.kubernetes.io/component":    "certificates-operator",
+						"app.kubernetes.io/instance":     "manager",
+						"app.kubernetes.io/managed-by":   "platform-config-operator",
+						"app.kubernetes.io/name":         "certificates-operator",
+						"app.kubernetes.io/part-of":      "platform",
+						"capabilities.tbd.io/capability": "certificates",
+						"control-plane":                  "controller-manager",
+					},
+				},
+			},
+		},
+	}
+
+	return mutate.MutatePodDisruptionBudgetNamespaceCertificatesOperatorControllerManager(resourceObj, parent, reconciler, req)
+}
@@ -20,6 +20,7 @@ package constants
 // package to prevent import cycle errors when attempting to reference the names from other
 // packages (e.g. mutate).
 const (
+	PriorityClassPlatformHigh                                          = "platform-high"
 	CRDCertmanagersCertificatesPlatformTbdIo                           = "certmanagers.certificates.platform.tbd.io"
 	CRDTrustmanagersCertificatesPlatformTbdIo                          = "trustmanagers.certificates.platform.tbd.io"
 	ServiceAccountNamespaceCertificatesOperatorControllerManager       = "certificates-operator-controller-manager"
@@ -32,6 +33,7 @@ const (
 	RoleBindingNamespaceCertificatesOperatorLeaderElectionRolebinding  = "certificates-operator-leader-election-rolebinding"
 	ClusterRoleBindingCertificatesOperatorManagerRolebinding           = "certificates-operator-manager-rolebinding"
 	DeploymentNamespaceCertificatesOperatorControllerManager           = "certificates-operator-controller-manager"
+	PodDisruptionBudgetNamespaceCertificatesOperatorControllerManager  = "certificates-operator-controller-manager"
 	CRDAwspodidentitywebhooksIdentityPlatformTbdIo                     = "awspodidentitywebhooks.identity.platform.tbd.io"
 	ServiceAccountNamespaceIdentityOperatorControllerManager           = "identity-operator-controller-manager"
 	RoleNamespaceIdentityOperatorLeaderElectionRole                    = "identity-operator-leader-election-role"
@@ -41,4 +43,5 @@ const (
 	RoleBindingNamespaceIdentityOperatorLeaderElectionRolebinding      = "identity-operator-leader-election-rolebinding"
 	ClusterRoleBindingIdentityOperatorManagerRolebinding               = "identity-operator-manager-rolebinding"
 	DeploymentNamespaceIdentityOperatorControllerManager               = "identity-operator-controller-manager"
+	PodDisruptionBudgetNamespaceIdentityOperatorControllerManager      = "identity-operator-controller-manager"
 )
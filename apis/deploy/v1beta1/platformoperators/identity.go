@@ -22,15 +22,17 @@ import (
 
 	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
 
-	deployv1alpha1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1alpha1"
-	"github.com/tbd-paas/platform-config-operator/apis/deploy/v1alpha1/platformoperators/mutate"
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+	"github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1/platformoperators/mutate"
+	"github.com/tbd-paas/platform-config-operator/internal/proxy"
+	"github.com/tbd-paas/platform-config-operator/internal/version"
 )
 
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch;create;update;patch;delete
 
 // CreateCRDAwspodidentitywebhooksIdentityPlatformTbdIo creates the CustomResourceDefinition resource with name awspodidentitywebhooks.identity.platform.tbd.io.
 func CreateCRDAwspodidentitywebhooksIdentityPlatformTbdIo(
-	parent *deployv1alpha1.PlatformOperators,
+	parent *deployv1beta1.PlatformOperators,
 	reconciler workload.Reconciler,
 	req *workload.Request,
 ) ([]client.Object, error) {
@@ -50,10 +52,10 @@ func CreateCRDAwspodidentitywebhooksIdentityPlatformTbdIo(
 					"app.kubernetes.io/managed-by":         "platform-config-operator",
 					"app.kubernetes.io/name":               "identity-operator",
 					"app.kubernetes.io/part-of":            "platform",
-					"app.kubernetes.io/version":            "unstable",
+					"app.kubernetes.io/version":            version.Version,
 					"capabilities.tbd.io/capability":       "platform-config",
-					"capabilities.tbd.io/platform-version": "unstable",
-					"capabilities.tbd.io/version":          "v0.0.1",
+					"capabilities.tbd.io/platform-version": version.Version,
+					"capabilities.tbd.io/version":          version.CapabilityVersion,
 				},
 				"name": "awspodidentitywebhooks.identity.platform.tbd.io",
 			},
@@ -286,7 +288,7 @@ of the controller reconciliation loop.`,
 
 // CreateServiceAccountNamespaceIdentityOperatorControllerManager creates the ServiceAccount resource with name identity-operator-controller-manager.
 func CreateServiceAccountNamespaceIdentityOperatorControllerManager(
-	parent *deployv1alpha1.PlatformOperators,
+	parent *deployv1beta1.PlatformOperators,
 	reconciler workload.Reconciler,
 	req *workload.Request,
 ) ([]client.Object, error) {
@@ -303,10 +305,10 @@ func CreateServiceAccountNamespaceIdentityOperatorControllerManager(
 					"app.kubernetes.io/managed-by":         "platform-config-operator",
 					"app.kubernetes.io/name":               "identity-operator",
 					"app.kubernetes.io/part-of":            "platform",
-					"app.kubernetes.io/version":            "unstable",
+					"app.kubernetes.io/version":            version.Version,
 					"capabilities.tbd.io/capability":       "platform-config",
-					"capabilities.tbd.io/platform-version": "unstable",
-					"capabilities.tbd.io/version":          "v0.0.1",
+					"capabilities.tbd.io/platform-version": version.Version,
+					"capabilities.tbd.io/version":          version.CapabilityVersion,
 				},
 				"name":      "identity-operator-controller-manager",
 				"namespace": parent.Spec.Namespace, //  controlled by field: namespace
@@ -324,7 +326,7 @@ func CreateServiceAccountNamespaceIdentityOperatorControllerManager(
 
 // CreateRoleNamespaceIdentityOperatorLeaderElectionRole creates the Role resource with name identity-operator-leader-election-role.
 func CreateRoleNamespaceIdentityOperatorLeaderElectionRole(
-	parent *deployv1alpha1.PlatformOperators,
+	parent *deployv1beta1.PlatformOperators,
 	reconciler workload.Reconciler,
 	req *workload.Request,
 ) ([]client.Object, error) {
@@ -341,10 +343,10 @@ func CreateRoleNamespaceIdentityOperatorLeaderElectionRole(
 					"app.kubernetes.io/managed-by":         "platform-config-operator",
 					"app.kubernetes.io/name":               "identity-operator",
 					"app.kubernetes.io/part-of":            "platform",
-					"app.kubernetes.io/version":            "unstable",
+					"app.kubernetes.io/version":            version.Version,
 					"capabilities.tbd.io/capability":       "platform-config",
-					"capabilities.tbd.io/platform-version": "unstable",
-					"capabilities.tbd.io/version":          "v0.0.1",
+					"capabilities.tbd.io/platform-version": version.Version,
+					"capabilities.tbd.io/version":          version.CapabilityVersion,
 				},
 				"name":      "identity-operator-leader-election-role",
 				"namespace": parent.Spec.Namespace, //  controlled by field: namespace
@@ -409,7 +411,7 @@ func CreateRoleNamespaceIdentityOperatorLeaderElectionRole(
 
 // CreateClusterRoleIdentityOperatorIdentityAwspodidentitywebhookEditorRole creates the ClusterRole resource with name identity-operator-identity-awspodidentitywebhook-editor-role.
 func CreateClusterRoleIdentityOperatorIdentityAwspodidentitywebhookEditorRole(
-	parent *deployv1alpha1.PlatformOperators,
+	parent *deployv1beta1.PlatformOperators,
 	reconciler workload.Reconciler,
 	req *workload.Request,
 ) ([]client.Object, error) {
@@ -426,10 +428,10 @@ func CreateClusterRoleIdentityOperatorIdentityAwspodidentitywebhookEditorRole(
 					"app.kubernetes.io/managed-by":         "platform-config-operator",
 					"app.kubernetes.io/name":               "identity-operator",
 					"app.kubernetes.io/part-of":            "platform",
-					"app.kubernetes.io/version":            "unstable",
+					"app.kubernetes.io/version":            version.Version,
 					"capabilities.tbd.io/capability":       "platform-config",
-					"capabilities.tbd.io/platform-version": "unstable",
-					"capabilities.tbd.io/version":          "v0.0.1",
+					"capabilities.tbd.io/platform-version": version.Version,
+					"capabilities.tbd.io/version":          version.CapabilityVersion,
 				},
 				"name": "identity-operator-identity-awspodidentitywebhook-editor-role",
 			},
@@ -475,7 +477,7 @@ func CreateClusterRoleIdentityOperatorIdentityAwspodidentitywebhookEditorRole(
 
 // CreateClusterRoleIdentityOperatorIdentityAwspodidentitywebhookViewerRole creates the ClusterRole resource with name identity-operator-identity-awspodidentitywebhook-viewer-role.
 func CreateClusterRoleIdentityOperatorIdentityAwspodidentitywebhookViewerRole(
-	parent *deployv1alpha1.PlatformOperators,
+	parent *deployv1beta1.PlatformOperators,
 	reconciler workload.Reconciler,
 	req *workload.Request,
 ) ([]client.Object, error) {
@@ -492,10 +494,10 @@ func CreateClusterRoleIdentityOperatorIdentityAwspodidentitywebhookViewerRole(
 					"app.kubernetes.io/managed-by":         "platform-config-operator",
 					"app.kubernetes.io/name":               "identity-operator",
 					"app.kubernetes.io/part-of":            "platform",
-					"app.kubernetes.io/version":            "unstable",
+					"app.kubernetes.io/version":            version.Version,
 					"capabilities.tbd.io/capability":       "platform-config",
-					"capabilities.tbd.io/platform-version": "unstable",
-					"capabilities.tbd.io/version":          "v0.0.1",
+					"capabilities.tbd.io/platform-version": version.Version,
+					"capabilities.tbd.io/version":          version.CapabilityVersion,
 				},
 				"name": "identity-operator-identity-awspodidentitywebhook-viewer-role",
 			},
@@ -549,7 +551,7 @@ func CreateClusterRoleIdentityOperatorIdentityAwspodidentitywebhookViewerRole(
 
 // CreateClusterRoleIdentityOperatorManagerRole creates the ClusterRole resource with name identity-operator-manager-role.
 func CreateClusterRoleIdentityOperatorManagerRole(
-	parent *deployv1alpha1.PlatformOperators,
+	parent *deployv1beta1.PlatformOperators,
 	reconciler workload.Reconciler,
 	req *workload.Request,
 ) ([]client.Object, error) {
@@ -566,10 +568,10 @@ func CreateClusterRoleIdentityOperatorManagerRole(
 					"app.kubernetes.io/managed-by":         "platform-config-operator",
 					"app.kubernetes.io/name":               "identity-operator",
 					"app.kubernetes.io/part-of":            "platform",
-					"app.kubernetes.io/version":            "unstable",
+					"app.kubernetes.io/version":            version.Version,
 					"capabilities.tbd.io/capability":       "platform-config",
-					"capabilities.tbd.io/platform-version": "unstable",
-					"capabilities.tbd.io/version":          "v0.0.1",
+					"capabilities.tbd.io/platform-version": version.Version,
+					"capabilities.tbd.io/version":          version.CapabilityVersion,
 				},
 				"name": "identity-operator-manager-role",
 			},
@@ -822,7 +824,7 @@ func CreateClusterRoleIdentityOperatorManagerRole(
 
 // CreateRoleBindingNamespaceIdentityOperatorLeaderElectionRolebinding creates the RoleBinding resource with name identity-operator-leader-election-rolebinding.
 func CreateRoleBindingNamespaceIdentityOperatorLeaderElectionRolebinding(
-	parent *deployv1alpha1.PlatformOperators,
+	parent *deployv1beta1.PlatformOperators,
 	reconciler workload.Reconciler,
 	req *workload.Request,
 ) ([]client.Object, error) {
@@ -839,10 +841,10 @@ func CreateRoleBindingNamespaceIdentityOperatorLeaderElectionRolebinding(
 					"app.kubernetes.io/managed-by":         "platform-config-operator",
 					"app.kubernetes.io/name":               "identity-operator",
 					"app.kubernetes.io/part-of":            "platform",
-					"app.kubernetes.io/version":            "unstable",
+					"app.kubernetes.io/version":            version.Version,
 					"capabilities.tbd.io/capability":       "platform-config",
-					"capabilities.tbd.io/platform-version": "unstable",
-					"capabilities.tbd.io/version":          "v0.0.1",
+					"capabilities.tbd.io/platform-version": version.Version,
+					"capabilities.tbd.io/version":          version.CapabilityVersion,
 				},
 				"name":      "identity-operator-leader-election-rolebinding",
 				"namespace": parent.Spec.Namespace, //  controlled by field: namespace
@@ -869,7 +871,7 @@ func CreateRoleBindingNamespaceIdentityOperatorLeaderElectionRolebinding(
 
 // CreateClusterRoleBindingIdentityOperatorManagerRolebinding creates the ClusterRoleBinding resource with name identity-operator-manager-rolebinding.
 func CreateClusterRoleBindingIdentityOperatorManagerRolebinding(
-	parent *deployv1alpha1.PlatformOperators,
+	parent *deployv1beta1.PlatformOperators,
 	reconciler workload.Reconciler,
 	req *workload.Request,
 ) ([]client.Object, error) {
@@ -886,10 +888,10 @@ func CreateClusterRoleBindingIdentityOperatorManagerRolebinding(
 					"app.kubernetes.io/managed-by":         "platform-config-operator",
 					"app.kubernetes.io/name":               "identity-operator",
 					"app.kubernetes.io/part-of":            "platform",
-					"app.kubernetes.io/version":            "unstable",
+					"app.kubernetes.io/version":            version.Version,
 					"capabilities.tbd.io/capability":       "platform-config",
-					"capabilities.tbd.io/platform-version": "unstable",
-					"capabilities.tbd.io/version":          "v0.0.1",
+					"capabilities.tbd.io/platform-version": version.Version,
+					"capabilities.tbd.io/version":          version.CapabilityVersion,
 				},
 				"name": "identity-operator-manager-rolebinding",
 			},
@@ -915,11 +917,18 @@ func CreateClusterRoleBindingIdentityOperatorManagerRolebinding(
 
 // CreateDeploymentNamespaceIdentityOperatorControllerManager creates the Deployment resource with name identity-operator-controller-manager.
 func CreateDeploymentNamespaceIdentityOperatorControllerManager(
-	parent *deployv1alpha1.PlatformOperators,
+	parent *deployv1beta1.PlatformOperators,
 	reconciler workload.Reconciler,
 	req *workload.Request,
 ) ([]client.Object, error) {
 
+	proxySpec, err := proxy.Resolve(reconciler, req, parent.Spec.Proxy)
+	if err != nil {
+		return nil, err
+	}
+
+	env := proxy.Env(proxySpec, parent.Spec.ExtraEnv)
+
 	var resourceObj = &unstructured.Unstructured{
 		Object: map[string]interface{}{
 			"apiVersion": "apps/v1",
@@ -932,30 +941,31 @@ func CreateDeploymentNamespaceIdentityOperatorControllerManager(
 					"app.kubernetes.io/managed-by":         "platform-config-operator",
 					"app.kubernetes.io/name":               "identity-operator",
 					"app.kubernetes.io/part-of":            "platform",
-					"app.kubernetes.io/version":            "unstable",
+					"app.kubernetes.io/version":            version.Version,
 					"capabilities.tbd.io/capability":       "platform-config",
-					"capabilities.tbd.io/platform-version": "unstable",
-					"capabilities.tbd.io/version":          "v0.0.1",
+					"capabilities.tbd.io/platform-version": version.Version,
+					"capabilities.tbd.io/version":          version.CapabilityVersion,
 					"control-plane":                        "controller-manager",
 				},
 				"name":      "identity-operator-controller-manager",
 				"namespace": parent.Spec.Namespace, //  controlled by field: namespace
 			},
 			"spec": map[string]interface{}{
-				"replicas": 1,
+				// controlled by field: operators.identity.replicas
+				//  Number of replicas to use for the operator for the identity capability.
+				"replicas": parent.Spec.Operators.Identity.Replicas,
+				// the selector of a deployment is immutable, so it omits the version labels, which change
+				// with every release and are only carried by the pod template
 				"selector": map[string]interface{}{
 					"matchLabels": map[string]interface{}{
-						"app":                                  "identity-operator",
-						"app.kubernetes.io/component":          "identity-operator",
-						"app.kubernetes.io/instance":           "manager",
-						"app.kubernetes.io/managed-by":         "platform-config-operator",
-						"app.kubernetes.io/name":               "identity-operator",
-						"app.kubernetes.io/part-of":            "platform",
-						"app.kubernetes.io/version":            "unstable",
-						"capabilities.tbd.io/capability":       "identity",
-						"capabilities.tbd.io/platform-version": "unstable",
-						"capabilities.tbd.io/version":          "v0.0.1",
-						"control-plane":                        "controller-manager",
+						"app":                            "identity-operator",
+						"app.kubernetes.io/component":    "identity-operator",
+						"app.kubernetes.io/instance":     "manager",
+						"app.kubernetes.io/managed-by":   "platform-config-operator",
+						"app.kubernetes.io/name":         "identity-operator",
+						"app.kubernetes.io/part-of":      "platform",
+						"capabilities.tbd.io/capability": "identity",
+						"control-plane":                  "controller-manager",
 					},
 				},
 				"template": map[string]interface{}{
@@ -970,10 +980,10 @@ func CreateDeploymentNamespaceIdentityOperatorControllerManager(
 							"app.kubernetes.io/managed-by":         "platform-config-operator",
 							"app.kubernetes.io/name":               "identity-operator",
 							"app.kubernetes.io/part-of":            "platform",
-							"app.kubernetes.io/version":            "unstable",
+							"app.kubernetes.io/version":            version.Version,
 							"capabilities.tbd.io/capability":       "identity",
-							"capabilities.tbd.io/platform-version": "unstable",
-							"capabilities.tbd.io/version":          "v0.0.1",
+							"capabilities.tbd.io/platform-version": version.Version,
+							"capabilities.tbd.io/version":          version.CapabilityVersion,
 							"control-plane":                        "controller-manager",
 						},
 					},
@@ -1042,10 +1052,15 @@ func CreateDeploymentNamespaceIdentityOperatorControllerManager(
 									"--health-probe-bind-address=:8081",
 									"--metrics-bind-address=127.0.0.1:8080",
 									"--leader-elect",
+									// controlled by field: operators.identity.logLevel
+									//  Log level of the operator for the identity capability.
+									"--zap-log-level=" + parent.Spec.Operators.Identity.LogLevel,
 								},
 								"command": []interface{}{
 									"/manager",
 								},
+								// controlled by fields: proxy, extraEnv
+								"env":   env,
 								"image": "quay.io/tbd-paas/identity-operator:v0.0.0-alpha.2",
 								"livenessProbe": map[string]interface{}{
 									"httpGet": map[string]interface{}{
@@ -1066,12 +1081,12 @@ func CreateDeploymentNamespaceIdentityOperatorControllerManager(
 								},
 								"resources": map[string]interface{}{
 									"limits": map[string]interface{}{
-										"cpu":    "125m",
-										"memory": "64Mi",
+										"cpu":    parent.Spec.Operators.Identity.Resources.Limits.CPU,    //  controlled by field: operators.identity.resources.limits.cpu
+										"memory": parent.Spec.Operators.Identity.Resources.Limits.Memory, //  controlled by field: operators.identity.resources.limits.memory
 									},
 									"requests": map[string]interface{}{
-										"cpu":    "10m",
-										"memory": "16Mi",
+										"cpu":    parent.Spec.Operators.Identity.Resources.Requests.CPU,    //  controlled by field: operators.identity.resources.requests.cpu
+										"memory": parent.Spec.Operators.Identity.Resources.Requests.Memory, //  controlled by field: operators.identity.resources.requests.memory
 									},
 								},
 								"securityContext": map[string]interface{}{
@@ -1095,7 +1110,12 @@ func CreateDeploymentNamespaceIdentityOperatorControllerManager(
 							"runAsGroup":   1001,
 							"runAsNonRoot": true,
 							"runAsUser":    1001,
+							"seccompProfile": map[string]interface{}{
+								"type": "RuntimeDefault",
+							},
 						},
+						// controlled by fields: priorityClassName, createPriorityClasses
+						"priorityClassName":             PriorityClassName(parent),
 						"serviceAccountName":            "identity-operator-controller-manager",
 						"terminationGracePeriodSeconds": 10,
 					},
@@ -1106,3 +1126,58 @@ func CreateDeploymentNamespaceIdentityOperatorControllerManager(
 
 	return mutate.MutateDeploymentNamespaceIdentityOperatorControllerManager(resourceObj, parent, reconciler, req)
 }
+
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+
+// CreatePodDisruptionBudgetNamespaceIdentityOperatorControllerManager creates the PodDisruptionBudget resource with name identity-operator-controller-manager.
+func CreatePodDisruptionBudgetNamespaceIdentityOperatorControllerManager(
+	parent *deployv1beta1.PlatformOperators,
+	reconciler workload.Reconciler,
+	req *workload.Request,
+) ([]client.Object, error) {
+
+	var resourceObj = &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "policy/v1",
+			"kind":       "PodDisruptionBudget",
+			"metadata": map[string]interface{}{
+				"labels": map[string]interface{}{
+					"app":                                  "identity-operator",
+					"app.kubernetes.io/component":          "identity-operator",
+					"app.kubernetes.io/instance":           "manager",
+					"app.kubernetes.io/managed-by":         "platform-config-operator",
+					"app.kubernetes.io/name":               "identity-operator",
+					"app.kubernetes.io/part-of":            "platform",
+					"app.kubernetes.io/version":            version.Version,
+					"capabilities.tbd.io/capability":       "platform-config",
+					"capabilities.tbd.io/platform-version": version.Version,
+					"capabilities.tbd.io/version":          version.CapabilityVersion,
+					"control-plane":                        "controller-manager",
+				},
+				"name":      "identity-operator-controller-manager",
+				"namespace": parent.Spec.Namespace, //  controlled by field: namespace
+			},
+			"spec": map[string]interface{}{
+				// the operator is leader elected, so a single replica may always be disrupted without
+				// blocking a drain, while additional replicas are kept available
+				"maxUnavailable":             1,
+				"unhealthyPodEvictionPolicy": "AlwaysAllow",
+				// the selector omits the version labels, matching the selector of the deployment
+				"selector": map[string]interface{}{
+					"matchLabels": map[string]interface{}{
+						"app":                            "identity-operator",
+						"app.kubernetes.io/component":    "identity-operator",
+						"app.kubernetes.io/instance":     "manager",
+						"app.kubernetes.io/managed-by":   "platform-config-operator",
+						"app.kubernetes.io/name":         "identity-operator",
+						"app.kubernetes.io/part-of":      "platform",
+						"capabilities.tbd.io/capability": "identity",
+						"control-plane":                  "controller-manager",
+					},
+				},
+			},
+		},
+	}
+
+	return mutate.MutatePodDisruptionBudgetNamespaceIdentityOperatorControllerManager(resourceObj, parent, reconciler, req)
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platformoperators
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+	"github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1/platformoperators/mutate"
+	"github.com/tbd-paas/platform-config-operator/internal/metrics"
+	"github.com/tbd-paas/platform-config-operator/internal/readiness"
+	"github.com/tbd-paas/platform-config-operator/internal/version"
+)
+
+// CreateMetrics creates the metrics Services of the capability operators, along with the RBAC which
+// their metrics endpoints require to authorize requests, and, once the CertManager resource is
+// ready, the Certificates of the metrics endpoints of the platform operators along with any
+// ServiceMonitors.  The metrics Service of this operator is installed alongside it.
+func CreateMetrics(
+	parent *deployv1beta1.PlatformOperators,
+	reconciler workload.Reconciler,
+	req *workload.Request,
+) ([]client.Object, error) {
+	resourceObjects := []client.Object{}
+
+	operators := MetricsOperators(parent)
+	for _, operator := range operators {
+		if operator != metrics.PlatformConfigOperator {
+			resourceObjects = append(resourceObjects, metrics.Service(operator, parent.Spec.Namespace, metricsLabels(operator)))
+			resourceObjects = append(resourceObjects, metrics.AuthRoles(operator, parent.Spec.Namespace, metricsLabels(operator))...)
+		}
+	}
+
+	if parent.Spec.Metrics.Certificate.Enabled != true {
+		return mutate.Overrides(parent, reconciler, req, resourceObjects...), nil
+	}
+
+	certificateObjects := []client.Object{}
+
+	for _, operator := range operators {
+		certificateObjects = append(certificateObjects, metrics.Certificate(
+			operator,
+			parent.Spec.Namespace,
+			parent.Spec.Metrics.Certificate.IssuerName, //  controlled by field: metrics.certificate.issuerName
+			metricsLabels(operator),
+		))
+
+		// controlled by field: metrics.serviceMonitors.enabled
+		if parent.Spec.Metrics.ServiceMonitors.Enabled && operator != metrics.PlatformConfigOperator {
+			certificateObjects = append(certificateObjects, metrics.ServiceMonitor(operator, parent.Spec.Namespace, metricsLabels(operator)))
+		}
+	}
+
+	// the Certificates are admitted once cert-manager is ready, until which only those which
+	// already exist are kept
+	ready, err := metrics.Ready(reconciler, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ready {
+		if certificateObjects, err = readiness.Existing(reconciler, req, certificateObjects); err != nil {
+			return nil, err
+		}
+	}
+
+	resourceObjects = append(resourceObjects, certificateObjects...)
+
+	return mutate.Overrides(parent, reconciler, req, resourceObjects...), nil
+}
+
+// MetricsOperators returns the names of the platform operators whose metrics endpoints are served
+// with a certificate, which are this operator and each of the enabled capability operators.
+func MetricsOperators(parent *deployv1beta1.PlatformOperators) []string {
+	operators := []string{metrics.PlatformConfigOperator}
+
+	if parent.Spec.Operators.Certificates.Enabled {
+		operators = append(operators, "certificates-operator")
+	}
+
+	if parent.Spec.Operators.Identity.Enabled {
+		operators = append(operators, "identity-operator")
+	}
+
+	return operators
+}
+
+// metricsLabels returns the labels of the metrics resources of an operator.
+func metricsLabels(operator string) map[string]interface{} {
+	return map[string]interface{}{
+		"app":                                  operator,
+		"app.kubernetes.io/component":          operator,
+		"app.kubernetes.io/instance":           "manager",
+		"app.kubernetes.io/managed-by":         "platform-config-operator",
+		"app.kubernetes.io/name":               operator,
+		"app.kubernetes.io/part-of":            "platform",
+		"app.kubernetes.io/version":            version.Version,
+		"capabilities.tbd.io/capability":       "platform-config",
+		"capabilities.tbd.io/platform-version": version.Version,
+		"capabilities.tbd.io/version":          version.CapabilityVersion,
+		"control-plane":                        "controller-manager",
+	}
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutate
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+	"github.com/tbd-paas/platform-config-operator/internal/mutate"
+)
+
+// Overrides applies the overrides of the parent which target each of the child resources, recording
+// the result of each override upon the status of the parent.  It is called last by every mutate
+// hook, and by the create functions of the child resources which have no mutate hook, so that the
+// overrides are applied to child resources as they are rendered.
+func Overrides(
+	parent *deployv1beta1.PlatformOperators,
+	reconciler workload.Reconciler, req *workload.Request,
+	originals ...client.Object,
+) []client.Object {
+	mutated := make([]client.Object, len(originals))
+	for i, original := range originals {
+		mutated[i] = mutate.Apply(reconciler, req, original, parent.Spec.Overrides, parent.Status.Overrides)
+	}
+
+	return mutated
+}
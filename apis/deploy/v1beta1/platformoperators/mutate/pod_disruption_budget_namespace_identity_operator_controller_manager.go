@@ -0,0 +1,42 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutate
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+)
+
+// MutatePodDisruptionBudgetNamespaceIdentityOperatorControllerManager mutates the PodDisruptionBudget resource with name identity-operator-controller-manager.
+func MutatePodDisruptionBudgetNamespaceIdentityOperatorControllerManager(
+	original client.Object,
+	parent *deployv1beta1.PlatformOperators,
+	reconciler workload.Reconciler, req *workload.Request,
+) ([]client.Object, error) {
+	// if either the reconciler or request are found to be nil, return the base object with the
+	// overrides applied.
+	if reconciler == nil || req == nil {
+		return Overrides(parent, reconciler, req, original), nil
+	}
+
+	// mutation logic goes here
+
+	return Overrides(parent, reconciler, req, original), nil
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platformoperators
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+	"github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1/platformoperators/mutate"
+	"github.com/tbd-paas/platform-config-operator/internal/version"
+)
+
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch;create;update;patch;delete
+
+// CreateNamespaceNamespace creates the Namespace resource with name parent.Spec.Namespace.
+func CreateNamespaceNamespace(
+	parent *deployv1beta1.PlatformOperators,
+	reconciler workload.Reconciler,
+	req *workload.Request,
+) ([]client.Object, error) {
+
+	var resourceObj = &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Namespace",
+			"metadata": map[string]interface{}{
+				// controlled by field: namespace
+				//  Namespace where the platform operators will be deployed.
+				"name": parent.Spec.Namespace,
+				"labels": map[string]interface{}{
+					"capabilities.tbd.io/capability":       "platform-config",
+					"capabilities.tbd.io/version":          version.CapabilityVersion,
+					"capabilities.tbd.io/platform-version": version.Version,
+					"app.kubernetes.io/version":            version.Version,
+					"app.kubernetes.io/part-of":            "platform",
+					"app.kubernetes.io/managed-by":         "platform-config-operator",
+					// the webhooks of the platform operators trust the platform CA
+					"certificates.platform.tbd.io/inject-ca-bundle": "true",
+					// controlled by field: podSecurity.enforce
+					"pod-security.kubernetes.io/enforce":         parent.Spec.PodSecurity.Enforce,
+					"pod-security.kubernetes.io/enforce-version": "latest",
+					// controlled by field: podSecurity.audit
+					"pod-security.kubernetes.io/audit":         parent.Spec.PodSecurity.Audit,
+					"pod-security.kubernetes.io/audit-version": "latest",
+					// controlled by field: podSecurity.warn
+					"pod-security.kubernetes.io/warn":         parent.Spec.PodSecurity.Warn,
+					"pod-security.kubernetes.io/warn-version": "latest",
+				},
+			},
+		},
+	}
+
+	return mutate.MutateNamespaceNamespace(resourceObj, parent, reconciler, req)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platformoperators
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+	"github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1/platformoperators/constants"
+	"github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1/platformoperators/mutate"
+	"github.com/tbd-paas/platform-config-operator/internal/version"
+)
+
+// PriorityClassName returns the name of the PriorityClass of the platform operators, which defaults
+// to the platform-high PriorityClass when it is created by the platform operators.
+func PriorityClassName(parent *deployv1beta1.PlatformOperators) string {
+	if parent.Spec.PriorityClassName == "" && parent.Spec.CreatePriorityClasses {
+		return constants.PriorityClassPlatformHigh
+	}
+
+	return parent.Spec.PriorityClassName
+}
+
+// +kubebuilder:rbac:groups=scheduling.k8s.io,resources=priorityclasses,verbs=get;list;watch;create;update;patch;delete
+
+// CreatePriorityClassPlatformHigh creates the PriorityClass resource with name platform-high.
+func CreatePriorityClassPlatformHigh(
+	parent *deployv1beta1.PlatformOperators,
+	reconciler workload.Reconciler,
+	req *workload.Request,
+) ([]client.Object, error) {
+
+	if parent.Spec.CreatePriorityClasses != true {
+		return []client.Object{}, nil
+	}
+
+	var resourceObj = &unstructured.Unstructured{
+		// +operator-builder:resource:field=createPriorityClasses,value=true,include
+		Object: map[string]interface{}{
+			"apiVersion": "scheduling.k8s.io/v1",
+			"kind":       "PriorityClass",
+			"metadata": map[string]interface{}{
+				"name": "platform-high",
+				"labels": map[string]interface{}{
+					"capabilities.tbd.io/capability":       "platform-config",
+					"capabilities.tbd.io/version":          version.CapabilityVersion,
+					"capabilities.tbd.io/platform-version": version.Version,
+					"app.kubernetes.io/version":            version.Version,
+					"app.kubernetes.io/part-of":            "platform",
+					"app.kubernetes.io/managed-by":         "platform-config-operator",
+				},
+			},
+			"value":            100000,
+			"globalDefault":    false,
+			"preemptionPolicy": "PreemptLowerPriority",
+			"description":      "Used by platform components which should not be preempted by tenant workloads.",
+		},
+	}
+
+	return mutate.MutatePriorityClassPlatformHigh(resourceObj, parent, reconciler, req)
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platformoperators
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+)
+
+func TestPriorityClasses(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name              string
+		create            bool
+		priorityClassName string
+
+		// created are the PriorityClasses which are generated, and expected is the PriorityClass
+		// of the operator Deployments
+		created  []string
+		expected string
+	}{
+		{name: "created", create: true, created: []string{"platform-high"}, expected: "platform-high"},
+		{name: "overridden", create: true, priorityClassName: "tenant-critical", created: []string{"platform-high"}, expected: "tenant-critical"},
+		{name: "not created", created: []string{}, expected: ""},
+		{name: "not created but referenced", priorityClassName: "platform-high", created: []string{}, expected: "platform-high"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			parent := &deployv1beta1.PlatformOperators{}
+			parent.Spec.Namespace = "tbd-operators-system"
+			parent.Spec.CreatePriorityClasses = tt.create
+			parent.Spec.PriorityClassName = tt.priorityClassName
+
+			require.Equal(t, tt.expected, PriorityClassName(parent))
+
+			objects, err := CreatePriorityClassPlatformHigh(parent, nil, nil)
+			require.NoError(t, err)
+
+			created := []string{}
+			for _, object := range objects {
+				created = append(created, object.GetName())
+			}
+
+			require.Equal(t, tt.created, created)
+
+			for _, create := range []func(*deployv1beta1.PlatformOperators) ([]client.Object, error){
+				func(parent *deployv1beta1.PlatformOperators) ([]client.Object, error) {
+					return CreateDeploymentNamespaceCertificatesOperatorControllerManager(parent, nil, nil)
+				},
+				func(parent *deployv1beta1.PlatformOperators) ([]client.Object, error) {
+					return CreateDeploymentNamespaceIdentityOperatorControllerManager(parent, nil, nil)
+				},
+			} {
+				deployments, err := create(parent)
+				require.NoError(t, err)
+				require.Len(t, deployments, 1)
+
+				// the generated objects hold values which may not be deep copied
+				priorityClassName, _, err := unstructured.NestedFieldNoCopy(
+					deployments[0].(*unstructured.Unstructured).Object,
+					"spec", "template", "spec", "priorityClassName",
+				)
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, priorityClassName, deployments[0].GetName())
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platformoperators
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+	"github.com/tbd-paas/platform-config-operator/internal/metadata"
+	"github.com/tbd-paas/platform-config-operator/internal/metrics"
+	"github.com/tbd-paas/platform-config-operator/internal/mutate"
+	"github.com/tbd-paas/platform-config-operator/internal/podsecurity"
+)
+
+// samplePlatformOperators is a sample containing all fields
+const samplePlatformOperators = `apiVersion: deploy.platform.tbd.io/v1beta1
+kind: PlatformOperators
+metadata:
+  name: platformoperators-sample
+spec:
+  namespace: "tbd-operators-system"
+  podSecurity:
+    enforce: "restricted"
+    audit: "restricted"
+    warn: "restricted"
+  networkPolicy:
+    mode: "default"
+    metricsScrapers:
+      podLabels:
+        app.kubernetes.io/name: "prometheus"
+  metrics:
+    certificate:
+      enabled: false
+      issuerName: "platform-root-ca"
+    serviceMonitors:
+      enabled: false
+  createPriorityClasses: true
+  operators:
+    certificates:
+      enabled: true
+      version: "v0.0.0-alpha.2"
+      replicas: 1
+      logLevel: "info"
+      resources:
+        requests:
+          cpu: "10m"
+          memory: "16Mi"
+        limits:
+          cpu: "125m"
+          memory: "64Mi"
+    identity:
+      enabled: true
+      version: "v0.0.0-alpha.2"
+      replicas: 1
+      logLevel: "info"
+      resources:
+        requests:
+          cpu: "10m"
+          memory: "16Mi"
+        limits:
+          cpu: "125m"
+          memory: "64Mi"
+`
+
+// samplePlatformOperatorsRequired is a sample containing only required fields
+const samplePlatformOperatorsRequired = `apiVersion: deploy.platform.tbd.io/v1beta1
+kind: PlatformOperators
+metadata:
+  name: platformoperators-sample
+spec:
+`
+
+// Sample returns the sample manifest for this custom resource.
+func Sample(requiredOnly bool) string {
+	if requiredOnly {
+		return samplePlatformOperatorsRequired
+	}
+
+	return samplePlatformOperators
+}
+
+// Generate returns the child resources that are associated with this workload given
+// appropriate structured inputs.
+func Generate(
+	workloadObj deployv1beta1.PlatformOperators,
+	reconciler workload.Reconciler,
+	req *workload.Request,
+) ([]client.Object, error) {
+	resourceObjects := []client.Object{}
+
+	// the mutate hooks record the result of applying each override as the child resources are rendered
+	workloadObj.Status.Overrides = mutate.Statuses(workloadObj.Spec.Overrides)
+
+	for _, f := range CreateFuncs {
+		resources, err := f(&workloadObj, reconciler, req)
+
+		if err != nil {
+			return nil, err
+		}
+
+		resourceObjects = append(resourceObjects, resources...)
+	}
+
+	// serve the metrics endpoints of the capability operators with their certificates, once issued
+	if err := metrics.Mount(resourceObjects); err != nil {
+		return nil, err
+	}
+
+	// apply the common labels and annotations, which may not override those the platform relies upon
+	if err := metadata.Apply(
+		reconciler,
+		req,
+		resourceObjects,
+		workloadObj.Spec.CommonLabels,
+		workloadObj.Spec.CommonAnnotations,
+	); err != nil {
+		return nil, err
+	}
+
+	// the overrides are applied by the mutate hooks as the child resources are rendered, which
+	// record the result of each upon the workload
+	if req == nil {
+		if err := mutate.OverridesError(workloadObj.Status.Overrides); err != nil {
+			return nil, err
+		}
+	} else {
+		component, err := ConvertWorkload(req.Workload)
+		if err != nil {
+			return nil, err
+		}
+
+		component.SetOverrideStatuses(workloadObj.Status.Overrides)
+	}
+
+	// refuse to enforce a pod security level which the generated workloads would violate
+	if err := podsecurity.Validate(
+		workloadObj.Spec.Namespace,
+		workloadObj.Spec.PodSecurity.Enforce,
+		resourceObjects,
+	); err != nil {
+		return nil, err
+	}
+
+	return resourceObjects, nil
+}
+
+// GenerateForCLI returns the child resources that are associated with this workload given
+// appropriate YAML manifest files.
+func GenerateForCLI(workloadFile []byte) ([]client.Object, error) {
+	var workloadObj deployv1beta1.PlatformOperators
+	if err := yaml.Unmarshal(workloadFile, &workloadObj); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal yaml into workload, %w", err)
+	}
+
+	if err := workload.Validate(&workloadObj); err != nil {
+		return nil, fmt.Errorf("error validating workload yaml, %w", err)
+	}
+
+	return Generate(workloadObj, nil, nil)
+}
+
+// CreateFuncs is an array of functions that are called to create the child resources for the controller
+// in memory during the reconciliation loop prior to persisting the changes or updates to the Kubernetes
+// database.
+var CreateFuncs = []func(
+	*deployv1beta1.PlatformOperators,
+	workload.Reconciler,
+	*workload.Request,
+) ([]client.Object, error){
+	CreateNamespaceNamespace,
+	CreatePriorityClassPlatformHigh,
+	CreateNetworkPoliciesNamespace,
+	CreateCertificatesOperator,
+	CreateIdentityOperator,
+	CreateMetrics,
+}
+
+// InitFuncs is an array of functions that are called prior to starting the controller manager.  This is
+// necessary in instances which the controller needs to "own" objects which depend on resources to
+// pre-exist in the cluster. A common use case for this is the need to own a custom resource.
+// If the controller needs to own a custom resource type, the CRD that defines it must
+// first exist. In this case, the InitFunc will create the CRD so that the controller
+// can own custom resources of that type.  Without the InitFunc the controller will
+// crash loop because when it tries to own a non-existent resource type during manager
+// setup, it will fail.
+var InitFuncs = []func(
+	*deployv1beta1.PlatformOperators,
+	workload.Reconciler,
+	*workload.Request,
+) ([]client.Object, error){
+	CreateCRDCertmanagersCertificatesPlatformTbdIo,
+	CreateCRDTrustmanagersCertificatesPlatformTbdIo,
+	CreateCRDAwspodidentitywebhooksIdentityPlatformTbdIo,
+}
+
+func ConvertWorkload(component workload.Workload) (*deployv1beta1.PlatformOperators, error) {
+	p, ok := component.(*deployv1beta1.PlatformOperators)
+	if !ok {
+		return nil, deployv1beta1.ErrUnableToConvertPlatformOperators
+	}
+
+	return p, nil
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platformoperators
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenerateTargetNamespace ensures that the namespace which the operators are deployed into is
+// generated, before any of the namespaced child resources which are deployed into it.
+func TestGenerateTargetNamespace(t *testing.T) {
+	t.Parallel()
+
+	sample := strings.Replace(samplePlatformOperators, `namespace: "tbd-operators-system"`, `namespace: "custom-operators"`, 1)
+	sample = strings.Replace(sample, `enforce: "restricted"`, `enforce: "baseline"`, 1)
+
+	objects, err := GenerateForCLI([]byte(sample))
+	require.NoError(t, err)
+	require.NotEmpty(t, objects)
+
+	namespace := objects[0]
+	require.Equal(t, "Namespace", namespace.GetObjectKind().GroupVersionKind().Kind)
+	require.Equal(t, "custom-operators", namespace.GetName())
+
+	labels := namespace.GetLabels()
+	require.Equal(t, "platform-config", labels["capabilities.tbd.io/capability"])
+	require.Equal(t, "platform-config-operator", labels["app.kubernetes.io/managed-by"])
+	require.Equal(t, "platform", labels["app.kubernetes.io/part-of"])
+	require.Equal(t, "baseline", labels["pod-security.kubernetes.io/enforce"])
+	require.Equal(t, "restricted", labels["pod-security.kubernetes.io/audit"])
+	require.Equal(t, "restricted", labels["pod-security.kubernetes.io/warn"])
+
+	namespaced := map[string]bool{}
+
+	for _, object := range objects[1:] {
+		kind := object.GetObjectKind().GroupVersionKind().Kind
+		require.NotEqual(t, "Namespace", kind, object.GetName())
+
+		if object.GetNamespace() == "" {
+			continue
+		}
+
+		require.Equal(t, "custom-operators", object.GetNamespace(), "%s %s", kind, object.GetName())
+
+		namespaced[kind] = true
+	}
+
+	// the service accounts and roles of the operators are created within the namespace
+	require.True(t, namespaced["ServiceAccount"])
+	require.True(t, namespaced["Role"])
+	require.True(t, namespaced["Deployment"])
+}
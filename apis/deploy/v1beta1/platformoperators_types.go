@@ -0,0 +1,356 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"errors"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"github.com/nukleros/operator-builder-tools/pkg/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var ErrUnableToConvertPlatformOperators = errors.New("unable to convert to PlatformOperators")
+
+// PlatformOperatorsSpec defines the desired state of PlatformOperators.
+type PlatformOperatorsSpec struct {
+	// Namespace where the platform operators will be deployed.
+	// +kubebuilder:default="tbd-operators-system"
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Pod Security Admission levels of the operators namespace.
+	// +kubebuilder:default={}
+	// +kubebuilder:validation:Optional
+	PodSecurity PodSecurity `json:"podSecurity,omitempty"`
+
+	// Whether the platform-high PriorityClass is created, so that the platform operators are not
+	// preempted by tenant workloads.  When disabled, any PriorityClass which is referenced by the
+	// platform must be created by other means.
+	// +kubebuilder:default=true
+	// +kubebuilder:validation:Optional
+	CreatePriorityClasses bool `json:"createPriorityClasses"`
+
+	// Name of the PriorityClass of the platform operators.  When empty, the platform-high
+	// PriorityClass is used if the PriorityClasses are created, and the default priority of the
+	// cluster otherwise.
+	// +kubebuilder:validation:Optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// Labels which are applied to every object which is generated for the platform operators,
+	// including the pods of workloads.  Keys which are reserved for the platform may not be set.
+	// Common labels take precedence over those which are generated for an object, except for the
+	// labels of pods, and labels which are no longer common are removed from the objects.
+	// +kubebuilder:validation:XValidation:rule="self.all(key, !key.matches('^([^/]+[.])?tbd[.]io/') && !key.startsWith('operator-builder.nukleros.io/') && !key.startsWith('pod-security.kubernetes.io/') && key != 'app.kubernetes.io/managed-by')",message="reserved keys may not be set"
+	// +kubebuilder:validation:Optional
+	CommonLabels map[string]string `json:"commonLabels,omitempty"`
+
+	// Annotations which are applied to every object which is generated for the platform operators,
+	// including the pods of workloads.  Keys which are reserved for the platform may not be set.
+	// Common annotations take precedence over those which are generated for an object, and
+	// annotations which are no longer common are removed from the objects.
+	// +kubebuilder:validation:XValidation:rule="self.all(key, !key.matches('^([^/]+[.])?tbd[.]io/') && !key.startsWith('operator-builder.nukleros.io/') && !key.startsWith('pod-security.kubernetes.io/') && key != 'app.kubernetes.io/managed-by')",message="reserved keys may not be set"
+	// +kubebuilder:validation:Optional
+	CommonAnnotations map[string]string `json:"commonAnnotations,omitempty"`
+
+	// HTTP(S) proxy which the platform operators use to reach external services.
+	// +kubebuilder:validation:Optional
+	Proxy Proxy `json:"proxy,omitempty"`
+
+	// Additional environment variables which are injected into the platform operators.
+	// +kubebuilder:validation:Optional
+	ExtraEnv []EnvVar `json:"extraEnv,omitempty"`
+
+	// Patches which are applied to the child resources which are generated for the platform operators,
+	// as an escape hatch for settings which are not otherwise modeled.  The result of applying each
+	// patch is reported in the status.
+	// +kubebuilder:validation:Optional
+	Overrides []Override `json:"overrides,omitempty"`
+
+	// Configuration for the individual platform operators.
+	// +kubebuilder:default={}
+	// +kubebuilder:validation:Optional
+	Operators PlatformOperatorsSpecOperators `json:"operators,omitempty"`
+
+	// Network isolation of the operators namespace.
+	// +kubebuilder:default={}
+	// +kubebuilder:validation:Optional
+	NetworkPolicy NetworkPolicy `json:"networkPolicy,omitempty"`
+
+	// Serving and scraping of the metrics endpoints of the platform operators.
+	// +kubebuilder:default={}
+	// +kubebuilder:validation:Optional
+	Metrics Metrics `json:"metrics,omitempty"`
+}
+
+type PlatformOperatorsSpecOperators struct {
+	// Configuration for the operator of the certificates capability.
+	// +kubebuilder:default={}
+	// +kubebuilder:validation:Optional
+	Certificates PlatformOperatorsSpecOperatorsCertificates `json:"certificates,omitempty"`
+
+	// Configuration for the operator of the identity capability.
+	// +kubebuilder:default={}
+	// +kubebuilder:validation:Optional
+	Identity PlatformOperatorsSpecOperatorsIdentity `json:"identity,omitempty"`
+}
+
+type PlatformOperatorsSpecOperatorsCertificates struct {
+	// Whether the operator for the certificates capability is deployed.  Disabling the operator
+	// removes any of its resources which were previously deployed.
+	// +kubebuilder:default=true
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled"`
+
+	// Version of the operator for the certificates capability.  Must be one of the versions
+	// which are embedded in this operator.
+	// +kubebuilder:default="v0.0.0-alpha.2"
+	// +kubebuilder:validation:Enum=v0.0.0-alpha.2
+	// +kubebuilder:validation:Optional
+	Version string `json:"version,omitempty"`
+
+	// Number of replicas to use for the operator for the certificates capability.
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	Replicas int `json:"replicas,omitempty"`
+
+	// Log level of the operator for the certificates capability.  Must be one of debug, info or error.
+	// +kubebuilder:default="info"
+	// +kubebuilder:validation:Enum=debug;info;error
+	// +kubebuilder:validation:Optional
+	LogLevel string `json:"logLevel,omitempty"`
+
+	// Resources to use for the operator for the certificates capability.
+	// +kubebuilder:default={}
+	// +kubebuilder:validation:Optional
+	Resources PlatformOperatorsSpecOperatorResources `json:"resources,omitempty"`
+}
+
+type PlatformOperatorsSpecOperatorsIdentity struct {
+	// Whether the operator for the identity capability is deployed.  Disabling the operator
+	// removes any of its resources which were previously deployed.
+	// +kubebuilder:default=true
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled"`
+
+	// Version of the operator for the identity capability.  Must be one of the versions
+	// which are embedded in this operator.
+	// +kubebuilder:default="v0.0.0-alpha.2"
+	// +kubebuilder:validation:Enum=v0.0.0-alpha.2
+	// +kubebuilder:validation:Optional
+	Version string `json:"version,omitempty"`
+
+	// Number of replicas to use for the operator for the identity capability.
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	Replicas int `json:"replicas,omitempty"`
+
+	// Log level of the operator for the identity capability.  Must be one of debug, info or error.
+	// +kubebuilder:default="info"
+	// +kubebuilder:validation:Enum=debug;info;error
+	// +kubebuilder:validation:Optional
+	LogLevel string `json:"logLevel,omitempty"`
+
+	// Resources to use for the operator for the identity capability.
+	// +kubebuilder:default={}
+	// +kubebuilder:validation:Optional
+	Resources PlatformOperatorsSpecOperatorResources `json:"resources,omitempty"`
+}
+
+type PlatformOperatorsSpecOperatorResources struct {
+	// Resource requests of the operator.
+	// +kubebuilder:default={}
+	// +kubebuilder:validation:Optional
+	Requests PlatformOperatorsSpecOperatorResourceRequests `json:"requests,omitempty"`
+
+	// Resource limits of the operator.
+	// +kubebuilder:default={}
+	// +kubebuilder:validation:Optional
+	Limits PlatformOperatorsSpecOperatorResourceLimits `json:"limits,omitempty"`
+}
+
+type PlatformOperatorsSpecOperatorResourceRequests struct {
+	// CPU requests of the operator.
+	// +kubebuilder:default="10m"
+	// +kubebuilder:validation:Optional
+	CPU string `json:"cpu,omitempty"`
+
+	// Memory requests of the operator.
+	// +kubebuilder:default="16Mi"
+	// +kubebuilder:validation:Optional
+	Memory string `json:"memory,omitempty"`
+}
+
+type PlatformOperatorsSpecOperatorResourceLimits struct {
+	// CPU limits of the operator.
+	// +kubebuilder:default="125m"
+	// +kubebuilder:validation:Optional
+	CPU string `json:"cpu,omitempty"`
+
+	// Memory limits of the operator.
+	// +kubebuilder:default="64Mi"
+	// +kubebuilder:validation:Optional
+	Memory string `json:"memory,omitempty"`
+}
+
+// PlatformOperatorsStatus defines the observed state of PlatformOperators.
+type PlatformOperatorsStatus struct {
+	Created               bool                     `json:"created,omitempty"`
+	DependenciesSatisfied bool                     `json:"dependenciesSatisfied,omitempty"`
+	Conditions            []*status.PhaseCondition `json:"conditions,omitempty"`
+	Resources             []*status.ChildResource  `json:"resources,omitempty"`
+
+	// Version of the platform release which last reconciled the child resources.
+	PlatformVersion string `json:"platformVersion,omitempty"`
+
+	// Results of applying the overrides to the child resources.
+	Overrides []OverrideStatus `json:"overrides,omitempty"`
+
+	// Readiness of the certificates of the metrics endpoints of the platform operators.
+	MetricsCertificates []MetricsCertificateStatus `json:"metricsCertificates,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:storageversion
+
+// PlatformOperators is the Schema for the platformoperators API.
+type PlatformOperators struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:default={}
+	Spec   PlatformOperatorsSpec   `json:"spec,omitempty"`
+	Status PlatformOperatorsStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PlatformOperatorsList contains a list of PlatformOperators.
+type PlatformOperatorsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PlatformOperators `json:"items"`
+}
+
+// interface methods
+
+// GetReadyStatus returns the ready status for a component.
+func (component *PlatformOperators) GetReadyStatus() bool {
+	return component.Status.Created
+}
+
+// SetReadyStatus sets the ready status for a component.
+func (component *PlatformOperators) SetReadyStatus(ready bool) {
+	component.Status.Created = ready
+}
+
+// GetDependencyStatus returns the dependency status for a component.
+func (component *PlatformOperators) GetDependencyStatus() bool {
+	return component.Status.DependenciesSatisfied
+}
+
+// SetDependencyStatus sets the dependency status for a component.
+func (component *PlatformOperators) SetDependencyStatus(dependencyStatus bool) {
+	component.Status.DependenciesSatisfied = dependencyStatus
+}
+
+// GetPhaseConditions returns the phase conditions for a component.
+func (component *PlatformOperators) GetPhaseConditions() []*status.PhaseCondition {
+	return component.Status.Conditions
+}
+
+// SetPhaseCondition sets the phase conditions for a component.
+func (component *PlatformOperators) SetPhaseCondition(condition *status.PhaseCondition) {
+	for i, currentCondition := range component.GetPhaseConditions() {
+		if currentCondition.Phase == condition.Phase {
+			component.Status.Conditions[i] = condition
+
+			return
+		}
+	}
+
+	// phase not found, lets add it to the list.
+	component.Status.Conditions = append(component.Status.Conditions, condition)
+}
+
+// GetResources returns the child resource status for a component.
+func (component *PlatformOperators) GetChildResourceConditions() []*status.ChildResource {
+	return component.Status.Resources
+}
+
+// SetResources sets the phase conditions for a component.
+func (component *PlatformOperators) SetChildResourceCondition(resource *status.ChildResource) {
+	for i, currentResource := range component.GetChildResourceConditions() {
+		if currentResource.Group == resource.Group && currentResource.Version == resource.Version && currentResource.Kind == resource.Kind {
+			if currentResource.Name == resource.Name && currentResource.Namespace == resource.Namespace {
+				component.Status.Resources[i] = resource
+
+				return
+			}
+		}
+	}
+
+	// phase not found, lets add it to the collection
+	component.Status.Resources = append(component.Status.Resources, resource)
+}
+
+// RemoveChildResourceCondition removes a child resource from the status of the component.
+func (component *PlatformOperators) RemoveChildResourceCondition(resource *status.ChildResource) {
+	for i, currentResource := range component.GetChildResourceConditions() {
+		if currentResource.Group == resource.Group && currentResource.Version == resource.Version && currentResource.Kind == resource.Kind {
+			if currentResource.Name == resource.Name && currentResource.Namespace == resource.Namespace {
+				component.Status.Resources = append(component.Status.Resources[:i], component.Status.Resources[i+1:]...)
+
+				return
+			}
+		}
+	}
+}
+
+// SetOverrideStatuses sets the results of applying the overrides to the child resources.
+func (component *PlatformOperators) SetOverrideStatuses(statuses []OverrideStatus) {
+	component.Status.Overrides = statuses
+}
+
+// SetMetricsCertificateStatuses sets the readiness of the certificates of the metrics endpoints.
+func (component *PlatformOperators) SetMetricsCertificateStatuses(statuses []MetricsCertificateStatus) {
+	component.Status.MetricsCertificates = statuses
+}
+
+// SetPlatformVersion sets the version of the platform release which reconciled the component.
+func (component *PlatformOperators) SetPlatformVersion(version string) {
+	component.Status.PlatformVersion = version
+}
+
+// GetDependencies returns the dependencies for a component.
+func (*PlatformOperators) GetDependencies() []workload.Workload {
+	return []workload.Workload{}
+}
+
+// GetComponentGVK returns a GVK object for the component.
+func (*PlatformOperators) GetWorkloadGVK() schema.GroupVersionKind {
+	return GroupVersion.WithKind("PlatformOperators")
+}
+
+func init() {
+	SchemeBuilder.Register(&PlatformOperators{}, &PlatformOperatorsList{})
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// PodSecurity defines the Pod Security Admission levels of a namespace.  See
+// https://kubernetes.io/docs/concepts/security/pod-security-standards/ for the meaning of each level.
+type PodSecurity struct {
+	// Level which pods in the namespace must satisfy to be admitted.  Must be a level which all of
+	// the workloads deployed into the namespace by the platform satisfy.
+	// +kubebuilder:default="restricted"
+	// +kubebuilder:validation:Enum=privileged;baseline;restricted
+	// +kubebuilder:validation:Optional
+	Enforce string `json:"enforce,omitempty"`
+
+	// Level which pods in the namespace are audited against.
+	// +kubebuilder:default="restricted"
+	// +kubebuilder:validation:Enum=privileged;baseline;restricted
+	// +kubebuilder:validation:Optional
+	Audit string `json:"audit,omitempty"`
+
+	// Level which pods in the namespace are warned against.
+	// +kubebuilder:default="restricted"
+	// +kubebuilder:validation:Enum=privileged;baseline;restricted
+	// +kubebuilder:validation:Optional
+	Warn string `json:"warn,omitempty"`
+}
+
+// PodSecurityViolation defines a pod security level which is not enforced upon a namespace, because
+// workloads which are deployed into the namespace violate it.
+type PodSecurityViolation struct {
+	// Namespace upon which the level is not enforced.
+	Namespace string `json:"namespace"`
+
+	// Level which was requested to be enforced.
+	Level string `json:"level"`
+
+	// Reason that the level is not enforced.
+	Message string `json:"message,omitempty"`
+}
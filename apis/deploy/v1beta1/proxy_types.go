@@ -0,0 +1,50 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// Proxy defines the HTTP(S) proxy which platform workloads use to reach external services.
+type Proxy struct {
+	// URL of the proxy for HTTP requests.
+	// +kubebuilder:validation:Optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// URL of the proxy for HTTPS requests.
+	// +kubebuilder:validation:Optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// Additional hosts, domains and CIDRs which are reached without the proxy.  Cluster local
+	// names and the service address of the API server are always reached without the proxy.
+	// +kubebuilder:validation:Optional
+	NoProxy []string `json:"noProxy,omitempty"`
+
+	// Service CIDR of the cluster, which is reached without the proxy.  Kubernetes does not expose the
+	// service CIDR, so services which are reached by address rather than by name require it to be set.
+	// +kubebuilder:validation:Optional
+	ServiceCIDR string `json:"serviceCIDR,omitempty"`
+}
+
+// EnvVar defines an environment variable which is injected into platform workloads.
+type EnvVar struct {
+	// Name of the environment variable.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Value of the environment variable.
+	// +kubebuilder:validation:Optional
+	Value string `json:"value,omitempty"`
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// ResourceBudget defines overrides to the resource budget of a capability namespace, which is
+// enforced by a ResourceQuota and LimitRange.  Values which are not set are derived from the
+// deployment size of the capability, and the quota is derived from the replicas and resources of
+// its components, including the surge of a rolling update, so that it never blocks a rollout.
+type ResourceBudget struct {
+	// Overrides to the total resources which may be consumed by the capability namespace.
+	// +kubebuilder:validation:Optional
+	Quota ResourceBudgetQuota `json:"quota,omitempty"`
+
+	// Overrides to the resources which are assigned to containers in the capability namespace
+	// which do not request them.
+	// +kubebuilder:validation:Optional
+	Defaults ResourceBudgetDefaults `json:"defaults,omitempty"`
+}
+
+// ResourceBudgetQuota defines overrides to the total resources of a capability namespace.
+type ResourceBudgetQuota struct {
+	// Total CPU requests of the capability namespace.
+	// +kubebuilder:validation:Optional
+	RequestsCPU string `json:"requestsCPU,omitempty"`
+
+	// Total memory requests of the capability namespace.
+	// +kubebuilder:validation:Optional
+	RequestsMemory string `json:"requestsMemory,omitempty"`
+
+	// Total CPU limits of the capability namespace.
+	// +kubebuilder:validation:Optional
+	LimitsCPU string `json:"limitsCPU,omitempty"`
+
+	// Total memory limits of the capability namespace.
+	// +kubebuilder:validation:Optional
+	LimitsMemory string `json:"limitsMemory,omitempty"`
+
+	// Total number of pods in the capability namespace.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	Pods int `json:"pods,omitempty"`
+}
+
+// ResourceBudgetDefaults defines overrides to the default resources of containers in a capability
+// namespace.
+type ResourceBudgetDefaults struct {
+	// Default CPU request of a container.
+	// +kubebuilder:validation:Optional
+	RequestCPU string `json:"requestCPU,omitempty"`
+
+	// Default memory request of a container.
+	// +kubebuilder:validation:Optional
+	RequestMemory string `json:"requestMemory,omitempty"`
+
+	// Default CPU limit of a container.
+	// +kubebuilder:validation:Optional
+	LimitCPU string `json:"limitCPU,omitempty"`
+
+	// Default memory limit of a container.
+	// +kubebuilder:validation:Optional
+	LimitMemory string `json:"limitMemory,omitempty"`
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// IdentityRoleBinding defines an IAM role which is assumed by the selected service accounts, by way
+// of the role-arn annotation of the AWS pod identity webhook.  A service account is selected when
+// both its namespace and itself are selected, by name or by labels.
+// +kubebuilder:validation:XValidation:rule="has(self.roleARN) != has(self.roleName)",message="exactly one of roleARN or roleName is required"
+// +kubebuilder:validation:XValidation:rule="has(self.namespaces) || has(self.namespaceSelector)",message="at least one of namespaces or namespaceSelector is required"
+// +kubebuilder:validation:XValidation:rule="has(self.serviceAccounts) || has(self.serviceAccountSelector)",message="at least one of serviceAccounts or serviceAccountSelector is required"
+type IdentityRoleBinding struct {
+	// Name of the binding, which identifies it in the status.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Names of the namespaces of the service accounts.
+	// +kubebuilder:validation:Optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Labels of the namespaces of the service accounts.  An empty selector selects every namespace.
+	// +kubebuilder:validation:Optional
+	NamespaceSelector map[string]string `json:"namespaceSelector,omitempty"`
+
+	// Names of the service accounts.
+	// +kubebuilder:validation:Optional
+	ServiceAccounts []string `json:"serviceAccounts,omitempty"`
+
+	// Labels of the service accounts.  An empty selector selects every service account of the selected
+	// namespaces.
+	// +kubebuilder:validation:Optional
+	ServiceAccountSelector map[string]string `json:"serviceAccountSelector,omitempty"`
+
+	// ARN of the IAM role which is assumed by the service accounts.
+	// +kubebuilder:validation:Pattern=`^arn:aws[a-z-]*:iam::[0-9]{12}:role/.+$`
+	// +kubebuilder:validation:Optional
+	RoleARN string `json:"roleARN,omitempty"`
+
+	// Name of the IAM role which is assumed by the service accounts, including its path if any, in
+	// the account of cloud.aws.accountID.
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9+=,.@_/-]+$`
+	// +kubebuilder:validation:Optional
+	RoleName string `json:"roleName,omitempty"`
+}
+
+// IdentityRoleBindingStatus defines the observed state of an IAM role binding.
+type IdentityRoleBindingStatus struct {
+	// Name of the binding.
+	Name string `json:"name"`
+
+	// ARN of the IAM role which is assumed by the service accounts.
+	RoleARN string `json:"roleARN,omitempty"`
+
+	// Number of service accounts which are annotated with the role.
+	ServiceAccounts int `json:"serviceAccounts"`
+
+	// Service accounts which are selected by the binding, but are not annotated with its role.
+	Conflicts []IdentityRoleBindingConflict `json:"conflicts,omitempty"`
+
+	// Reason for which the binding is not applied.
+	Message string `json:"message,omitempty"`
+}
+
+// IdentityRoleBindingConflict defines a service account which is not annotated with the role of a
+// binding which selects it.
+type IdentityRoleBindingConflict struct {
+	// Namespace of the service account.
+	Namespace string `json:"namespace"`
+
+	// Name of the service account.
+	ServiceAccount string `json:"serviceAccount"`
+
+	// Reason for which the service account is not annotated.
+	Message string `json:"message"`
+}
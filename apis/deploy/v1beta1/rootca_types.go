@@ -0,0 +1,92 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	RootCAPhaseActive   = "Active"
+	RootCAPhaseRotating = "Rotating"
+)
+
+// RootCA defines the private root certificate authority which is generated and rotated by the
+// operator for local clusters.  Changes to the key and subject take effect at the next rotation.
+// +kubebuilder:validation:XValidation:rule="(self.keyAlgorithm == 'ECDSA' && self.keySize in [256, 384]) || (self.keyAlgorithm == 'RSA' && self.keySize in [2048, 3072, 4096])",message="keySize must be one of 256 or 384 for ECDSA and one of 2048, 3072 or 4096 for RSA"
+// +kubebuilder:validation:XValidation:rule="duration(self.renewBefore) < duration(self.validity)",message="renewBefore must be less than validity"
+type RootCA struct {
+	// Algorithm of the private key of the root CA.
+	// +kubebuilder:default="ECDSA"
+	// +kubebuilder:validation:Enum=ECDSA;RSA
+	// +kubebuilder:validation:Optional
+	KeyAlgorithm string `json:"keyAlgorithm,omitempty"`
+
+	// Size of the private key of the root CA, in bits.  Must be one of 256 or 384 for ECDSA and one of
+	// 2048, 3072 or 4096 for RSA.
+	// +kubebuilder:default=256
+	// +kubebuilder:validation:Optional
+	KeySize int `json:"keySize,omitempty"`
+
+	// Duration for which the root CA is valid.
+	// +kubebuilder:default="87600h"
+	// +kubebuilder:validation:Optional
+	Validity metav1.Duration `json:"validity,omitempty"`
+
+	// Duration before the expiry of the root CA at which it is rotated.
+	// +kubebuilder:default="720h"
+	// +kubebuilder:validation:Optional
+	RenewBefore metav1.Duration `json:"renewBefore,omitempty"`
+
+	// Subject of the root CA.
+	// +kubebuilder:default={}
+	// +kubebuilder:validation:Optional
+	Subject RootCASubject `json:"subject,omitempty"`
+}
+
+// RootCASubject defines the subject of the root CA.
+type RootCASubject struct {
+	// Common name of the root CA.
+	// +kubebuilder:default="tbd platform root CA"
+	// +kubebuilder:validation:Optional
+	CommonName string `json:"commonName,omitempty"`
+
+	// Organizations of the root CA.
+	// +kubebuilder:validation:Optional
+	Organizations []string `json:"organizations,omitempty"`
+
+	// Organizational units of the root CA.
+	// +kubebuilder:validation:Optional
+	OrganizationalUnits []string `json:"organizationalUnits,omitempty"`
+
+	// Countries of the root CA.
+	// +kubebuilder:validation:Optional
+	Countries []string `json:"countries,omitempty"`
+}
+
+// RootCAStatus defines the observed state of the root CA.
+type RootCAStatus struct {
+	// Phase of the root CA.  A root CA is Rotating from when it is replaced until every certificate
+	// which was issued by the previous root CA is reissued, throughout which both are trusted.
+	Phase string `json:"phase"`
+
+	// Time at which the current root CA expires.
+	NotAfter metav1.Time `json:"notAfter,omitempty"`
+
+	// Number of certificates which must be reissued by the current root CA before rotation completes.
+	PendingCertificates int `json:"pendingCertificates,omitempty"`
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// ServiceAccountIssuerDiscovery defines the publication of the OIDC discovery document and JSON web
+// key set of the service account issuer of a local cloud, so that the projected service account
+// tokens which are injected by the AWS pod identity webhook may be validated by IAM or a local STS.
+// The documents are published to a ConfigMap in the identity namespace, under the keys
+// openid-configuration and keys.json, to be served from the issuer URL.
+type ServiceAccountIssuerDiscovery struct {
+	// Whether the discovery documents are published.  Ignored when the cloud is not local.
+	// +kubebuilder:default=true
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled"`
+
+	// URL from which the discovery documents are served, which must match the issuer of the service
+	// account tokens, as set by the first --service-account-issuer flag of the API server.  When set,
+	// it is validated against the issuer which is reported by the API server, and the documents are
+	// not published when they differ.  When not set, the issuer of the API server is used.
+	// +kubebuilder:validation:Pattern=`^https://`
+	// +kubebuilder:validation:Optional
+	IssuerURL string `json:"issuerURL,omitempty"`
+
+	// Name of the ConfigMap, in the identity namespace, which the discovery documents are published to.
+	// +kubebuilder:default="service-account-issuer-discovery"
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+}
+
+// ServiceAccountIssuerDiscoveryStatus defines the observed state of the publication of the service
+// account issuer discovery documents.
+type ServiceAccountIssuerDiscoveryStatus struct {
+	// Issuer which the discovery documents are published for.
+	Issuer string `json:"issuer,omitempty"`
+
+	// Whether the discovery documents are published.
+	Published bool `json:"published"`
+
+	// Reason for which the discovery documents are not published, or for which tokens may fail to
+	// validate against them.
+	Message string `json:"message,omitempty"`
+}
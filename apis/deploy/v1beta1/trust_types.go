@@ -0,0 +1,129 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// Trust defines the trust bundles which are distributed to namespaces by trust-manager.
+type Trust struct {
+	// Bundles of certificate authorities which are distributed to namespaces.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:Optional
+	Bundles []TrustBundle `json:"bundles,omitempty"`
+
+	// Namespaces which receive the bundles that do not select namespaces themselves.
+	// +kubebuilder:default={}
+	// +kubebuilder:validation:Optional
+	Injection TrustInjection `json:"injection,omitempty"`
+}
+
+// TrustInjection defines the namespaces which receive the trust bundles that do not select
+// namespaces themselves.  The selected namespaces are labeled with
+// certificates.platform.tbd.io/ca-bundle-target=true by the operator.
+type TrustInjection struct {
+	// Labels of the namespaces which receive the bundles.  The namespaces of the platform components
+	// are labeled with certificates.platform.tbd.io/inject-ca-bundle=true.
+	// +kubebuilder:default={"certificates.platform.tbd.io/inject-ca-bundle":"true"}
+	// +kubebuilder:validation:MinProperties=1
+	// +kubebuilder:validation:Optional
+	NamespaceSelector map[string]string `json:"namespaceSelector,omitempty"`
+
+	// Namespaces which receive the bundles regardless of their labels.
+	// +kubebuilder:validation:Optional
+	IncludeNamespaces []string `json:"includeNamespaces,omitempty"`
+
+	// Namespaces which never receive the bundles, which takes precedence over both the selector and
+	// the included namespaces.
+	// +kubebuilder:validation:Optional
+	ExcludeNamespaces []string `json:"excludeNamespaces,omitempty"`
+}
+
+// TrustBundle defines a trust-manager Bundle.
+type TrustBundle struct {
+	// Name of the Bundle, which is also the name of the ConfigMap or Secret which is created in each
+	// target namespace.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Certificate authorities which are included in the bundle.
+	// +kubebuilder:default={}
+	// +kubebuilder:validation:Optional
+	Sources TrustBundleSources `json:"sources,omitempty"`
+
+	// Namespaces and objects which the bundle is written to.
+	// +kubebuilder:default={}
+	// +kubebuilder:validation:Optional
+	Target TrustBundleTarget `json:"target,omitempty"`
+}
+
+// TrustBundleSources defines the certificate authorities of a trust bundle.
+// +kubebuilder:validation:XValidation:rule="(has(self.useDefaultCAs) && self.useDefaultCAs) || (has(self.platformRootCA) && self.platformRootCA) || has(self.secrets) || has(self.configMaps)",message="at least one source is required"
+type TrustBundleSources struct {
+	// Whether the default certificate authorities, as packaged with trust-manager, are included.
+	// +kubebuilder:validation:Optional
+	UseDefaultCAs bool `json:"useDefaultCAs,omitempty"`
+
+	// Whether the private root CA of a local cloud is included, along with its predecessor while it
+	// is rotating.  Ignored when the cloud is not local.
+	// +kubebuilder:default=true
+	// +kubebuilder:validation:Optional
+	PlatformRootCA bool `json:"platformRootCA"`
+
+	// Keys of Secrets, in the namespace of the certificates capability, holding PEM encoded
+	// certificate authorities which are included.
+	// +kubebuilder:validation:Optional
+	Secrets []TrustBundleSource `json:"secrets,omitempty"`
+
+	// Keys of ConfigMaps, in the namespace of the certificates capability, holding PEM encoded
+	// certificate authorities which are included.
+	// +kubebuilder:validation:Optional
+	ConfigMaps []TrustBundleSource `json:"configMaps,omitempty"`
+}
+
+// TrustBundleSource defines a key of a Secret or ConfigMap which holds PEM encoded certificate
+// authorities.
+type TrustBundleSource struct {
+	// Name of the Secret or ConfigMap.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Key of the Secret or ConfigMap.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Required
+	Key string `json:"key"`
+}
+
+// TrustBundleTarget defines the namespaces and objects which a trust bundle is written to.
+type TrustBundleTarget struct {
+	// Kind of the object which the bundle is written to.  Must be one of ConfigMap or Secret.
+	// +kubebuilder:default="ConfigMap"
+	// +kubebuilder:validation:Enum=ConfigMap;Secret
+	// +kubebuilder:validation:Optional
+	Kind string `json:"kind,omitempty"`
+
+	// Key of the object which the bundle is written to.
+	// +kubebuilder:default="ca-bundle.crt"
+	// +kubebuilder:validation:Optional
+	Key string `json:"key,omitempty"`
+
+	// Labels of the namespaces which the bundle is written to.  When not set, the bundle is written to
+	// the namespaces which are selected by the injection settings.
+	// +kubebuilder:validation:Optional
+	NamespaceSelector map[string]string `json:"namespaceSelector,omitempty"`
+}
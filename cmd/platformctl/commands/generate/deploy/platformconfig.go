@@ -32,6 +32,7 @@ import (
 	cmdgenerate "github.com/tbd-paas/platform-config-operator/cmd/platformctl/commands/generate"
 	// specific imports for workloads
 	v1alpha1platformconfig "github.com/tbd-paas/platform-config-operator/apis/deploy/v1alpha1/platformconfig"
+	v1beta1platformconfig "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1/platformconfig"
 	// +kubebuilder:scaffold:operator-builder:imports
 )
 
@@ -75,6 +76,7 @@ func GeneratePlatformConfig(g *cmdgenerate.GenerateSubCommand) error {
 	type generateFunc func([]byte) ([]client.Object, error)
 	generateFuncMap := map[string]generateFunc{
 		"v1alpha1": v1alpha1platformconfig.GenerateForCLI,
+		"v1beta1":  v1beta1platformconfig.GenerateForCLI,
 		// +kubebuilder:scaffold:operator-builder:versionmap
 	}
 
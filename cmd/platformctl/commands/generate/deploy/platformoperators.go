@@ -32,6 +32,7 @@ import (
 	cmdgenerate "github.com/tbd-paas/platform-config-operator/cmd/platformctl/commands/generate"
 	// specific imports for workloads
 	v1alpha1platformoperators "github.com/tbd-paas/platform-config-operator/apis/deploy/v1alpha1/platformoperators"
+	v1beta1platformoperators "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1/platformoperators"
 	// +kubebuilder:scaffold:operator-builder:imports
 )
 
@@ -75,6 +76,7 @@ func GeneratePlatformOperators(g *cmdgenerate.GenerateSubCommand) error {
 	type generateFunc func([]byte) ([]client.Object, error)
 	generateFuncMap := map[string]generateFunc{
 		"v1alpha1": v1alpha1platformoperators.GenerateForCLI,
+		"v1beta1":  v1beta1platformoperators.GenerateForCLI,
 		// +kubebuilder:scaffold:operator-builder:versionmap
 	}
 
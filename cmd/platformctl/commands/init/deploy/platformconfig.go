@@ -25,6 +25,7 @@ import (
 	"github.com/tbd-paas/platform-config-operator/apis/deploy"
 
 	v1alpha1platformconfig "github.com/tbd-paas/platform-config-operator/apis/deploy/v1alpha1/platformconfig"
+	v1beta1platformconfig "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1/platformconfig"
 	cmdinit "github.com/tbd-paas/platform-config-operator/cmd/platformctl/commands/init"
 	// +kubebuilder:scaffold:operator-builder:imports
 )
@@ -40,6 +41,7 @@ func getPlatformConfigManifest(i *cmdinit.InitSubCommand) (string, error) {
 	// generate a map of all versions to samples for each api version created
 	manifestMap := map[string]string{
 		"v1alpha1": v1alpha1platformconfig.Sample(i.RequiredOnly),
+		"v1beta1":  v1beta1platformconfig.Sample(i.RequiredOnly),
 		// +kubebuilder:scaffold:operator-builder:versionmap
 	}
 
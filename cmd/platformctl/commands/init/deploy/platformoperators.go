@@ -25,6 +25,7 @@ import (
 	"github.com/tbd-paas/platform-config-operator/apis/deploy"
 
 	v1alpha1platformoperators "github.com/tbd-paas/platform-config-operator/apis/deploy/v1alpha1/platformoperators"
+	v1beta1platformoperators "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1/platformoperators"
 	cmdinit "github.com/tbd-paas/platform-config-operator/cmd/platformctl/commands/init"
 	// +kubebuilder:scaffold:operator-builder:imports
 )
@@ -40,6 +41,7 @@ func getPlatformOperatorsManifest(i *cmdinit.InitSubCommand) (string, error) {
 	// generate a map of all versions to samples for each api version created
 	manifestMap := map[string]string{
 		"v1alpha1": v1alpha1platformoperators.Sample(i.RequiredOnly),
+		"v1beta1":  v1beta1platformoperators.Sample(i.RequiredOnly),
 		// +kubebuilder:scaffold:operator-builder:versionmap
 	}
 
@@ -22,6 +22,7 @@ import (
 	cmdversion "github.com/tbd-paas/platform-config-operator/cmd/platformctl/commands/version"
 
 	"github.com/tbd-paas/platform-config-operator/apis/deploy"
+	"github.com/tbd-paas/platform-config-operator/internal/version"
 )
 
 // NewPlatformConfigSubCommand creates a new command and adds it to its
@@ -45,8 +46,9 @@ func VersionPlatformConfig(v *cmdversion.VersionSubCommand) error {
 	}
 
 	versionInfo := cmdversion.VersionInfo{
-		CLIVersion:  cmdversion.CLIVersion,
-		APIVersions: apiVersions,
+		CLIVersion:        version.Version,
+		CapabilityVersion: version.CapabilityVersion,
+		APIVersions:       apiVersions,
 	}
 
 	return versionInfo.Display()
@@ -24,11 +24,10 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var CLIVersion = "dev"
-
 type VersionInfo struct {
-	CLIVersion  string   `json:"cliVersion"`
-	APIVersions []string `json:"apiVersions"`
+	CLIVersion        string   `json:"cliVersion"`
+	CapabilityVersion string   `json:"capabilityVersion"`
+	APIVersions       []string `json:"apiVersions"`
 }
 
 type VersionFunc func(*VersionSubCommand) error
@@ -0,0 +1,41 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"github.com/nukleros/operator-builder-tools/pkg/controller/phases"
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+
+	"github.com/tbd-paas/platform-config-operator/internal/version"
+)
+
+// platformVersionSetter is a workload which records the version of the platform release which
+// reconciled it.
+type platformVersionSetter interface {
+	SetPlatformVersion(string)
+}
+
+// completePhase completes the reconciliation of a workload, recording the version of the platform
+// release which reconciled its child resources.  The status of the workload is persisted when the
+// phase exits.
+func completePhase(r workload.Reconciler, req *workload.Request, options ...phases.ResourceOption) (bool, error) {
+	if setter, ok := req.Workload.(platformVersionSetter); ok {
+		setter.SetPlatformVersion(version.Version)
+	}
+
+	return phases.CompletePhase(r, req, options...)
+}
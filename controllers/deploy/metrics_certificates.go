@@ -0,0 +1,42 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"github.com/nukleros/operator-builder-tools/pkg/controller/phases"
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+
+	"github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1/platformoperators"
+)
+
+// metricsCertificatesPhase holds a PlatformOperators for requeue until the certificates of the
+// metrics endpoints are issued, as neither the readiness of the certificates capability nor the
+// issuance of the certificates triggers a reconciliation.
+func metricsCertificatesPhase(r workload.Reconciler, req *workload.Request, options ...phases.ResourceOption) (bool, error) {
+	component, err := platformoperators.ConvertWorkload(req.Workload)
+	if err != nil {
+		return false, err
+	}
+
+	for _, certificate := range component.Status.MetricsCertificates {
+		if !certificate.Ready {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
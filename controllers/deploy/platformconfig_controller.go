@@ -31,10 +31,16 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
-	deployv1alpha1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1alpha1"
-	"github.com/tbd-paas/platform-config-operator/apis/deploy/v1alpha1/platformconfig"
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+	"github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1/platformconfig"
 	"github.com/tbd-paas/platform-config-operator/internal/dependencies"
+	"github.com/tbd-paas/platform-config-operator/internal/desired"
+	"github.com/tbd-paas/platform-config-operator/internal/disruption"
 	"github.com/tbd-paas/platform-config-operator/internal/mutate"
+	"github.com/tbd-paas/platform-config-operator/internal/oidcdiscovery"
+	"github.com/tbd-paas/platform-config-operator/internal/prune"
+	"github.com/tbd-paas/platform-config-operator/internal/rootca"
+	"github.com/tbd-paas/platform-config-operator/internal/watches"
 )
 
 // PlatformConfigReconciler reconciles a PlatformConfig object.
@@ -48,6 +54,8 @@ type PlatformConfigReconciler struct {
 	Watches      []client.Object
 	Phases       *phases.Registry
 	Manager      manager.Manager
+	Desired      *desired.Cache
+	Discovery    *oidcdiscovery.Cache
 }
 
 func NewPlatformConfigReconciler(mgr ctrl.Manager) *PlatformConfigReconciler {
@@ -60,6 +68,8 @@ func NewPlatformConfigReconciler(mgr ctrl.Manager) *PlatformConfigReconciler {
 		Watches:      []client.Object{},
 		Phases:       &phases.Registry{},
 		Manager:      mgr,
+		Desired:      &desired.Cache{},
+		Discovery:    &oidcdiscovery.Cache{},
 	}
 }
 
@@ -67,6 +77,7 @@ func NewPlatformConfigReconciler(mgr ctrl.Manager) *PlatformConfigReconciler {
 // +kubebuilder:rbac:groups=deploy.platform.tbd.io,resources=platformconfigs/status,verbs=get;update;patch
 
 // +kubebuilder:rbac:groups=core,resources=namespaces,verbs=list;watch
+// +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=list;watch
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;create;update;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -85,16 +96,48 @@ func (r *PlatformConfigReconciler) Reconcile(ctx context.Context, request ctrl.R
 		return ctrl.Result{}, nil
 	}
 
+	// the child resources are generated once for the whole reconciliation
+	defer r.Desired.Forget(req)
+
 	if err := phases.RegisterDeleteHooks(r, req); err != nil {
 		return ctrl.Result{}, err
 	}
 
+	// a workload which is being deleted only runs its delete phases, so that a failure to generate
+	// or prune its child resources does not keep its finalizer from being removed
+	if !req.Workload.GetDeletionTimestamp().IsZero() {
+		return r.Phases.HandleExecution(r, req)
+	}
+
+	// watch the kinds of all child resources so that drift is corrected as it happens rather
+	// than on the next requeue
+	children, err := r.GetResources(req)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := watches.Children(r, req, children); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// the rotation of the private root CA progresses as the certificates which it issued are reissued
+	if err := watches.Certificates(r, req, func() client.ObjectList {
+		return &deployv1beta1.PlatformConfigList{}
+	}, rootca.FromIssuer); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// remove any child resources which were previously persisted but are no longer desired
+	if err := prune.Orphans(r, req, children); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// execute the phases
 	return r.Phases.HandleExecution(r, req)
 }
 
 func (r *PlatformConfigReconciler) NewRequest(ctx context.Context, request ctrl.Request) (*workload.Request, error) {
-	component := &deployv1alpha1.PlatformConfig{}
+	component := &deployv1beta1.PlatformConfig{}
 
 	log := r.Log.WithValues(
 		"kind", component.GetWorkloadGVK().Kind,
@@ -123,14 +166,17 @@ func (r *PlatformConfigReconciler) NewRequest(ctx context.Context, request ctrl.
 	return workloadRequest, nil
 }
 
-// GetResources resources runs the methods to properly construct the resources in memory.
+// GetResources resources runs the methods to properly construct the resources in memory, once per
+// reconciliation.
 func (r *PlatformConfigReconciler) GetResources(req *workload.Request) ([]client.Object, error) {
-	component, err := platformconfig.ConvertWorkload(req.Workload)
-	if err != nil {
-		return nil, err
-	}
+	return r.Desired.Get(req, func() ([]client.Object, error) {
+		component, err := platformconfig.ConvertWorkload(req.Workload)
+		if err != nil {
+			return nil, err
+		}
 
-	return platformconfig.Generate(*component, r, req)
+		return platformconfig.Generate(*component, r, req)
+	})
 }
 
 // GetEventRecorder returns the event recorder for writing kubernetes events.
@@ -163,6 +209,11 @@ func (r *PlatformConfigReconciler) GetManager() manager.Manager {
 	return r.Manager
 }
 
+// GetDiscoveryCache returns the cache of the service account issuer discovery documents.
+func (r *PlatformConfigReconciler) GetDiscoveryCache() *oidcdiscovery.Cache {
+	return r.Discovery
+}
+
 // GetWatches returns the objects which are current being watched by the reconciler.
 func (r *PlatformConfigReconciler) GetWatches() []client.Object {
 	return r.Watches
@@ -192,7 +243,7 @@ func (r *PlatformConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 	baseController, err := ctrl.NewControllerManagedBy(mgr).
 		WithEventFilter(predicates.WorkloadPredicates()).
-		For(&deployv1alpha1.PlatformConfig{}).
+		For(&deployv1beta1.PlatformConfig{}).
 		Build(r)
 	if err != nil {
 		return fmt.Errorf("unable to setup controller, %w", err)
@@ -200,5 +251,28 @@ func (r *PlatformConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 	r.Controller = baseController
 
+	// the namespaces which receive the trust bundles are selected by their labels
+	if err := watches.Namespaces(mgr, baseController, func() client.ObjectList {
+		return &deployv1beta1.PlatformConfigList{}
+	}); err != nil {
+		return fmt.Errorf("unable to setup controller, %w", err)
+	}
+
+	// the pod security levels are validated against, and the disruption budgets select the pods of, the
+	// Deployments which the capability operators create, and the environment is injected again when
+	// those operators replace the containers
+	if err := watches.Deployments(mgr, baseController, func() client.ObjectList {
+		return &deployv1beta1.PlatformConfigList{}
+	}, disruption.IsCapabilityDeployment); err != nil {
+		return fmt.Errorf("unable to setup controller, %w", err)
+	}
+
+	// the service accounts which assume IAM roles are selected by their names and labels
+	if err := watches.ServiceAccounts(mgr, baseController, func() client.ObjectList {
+		return &deployv1beta1.PlatformConfigList{}
+	}); err != nil {
+		return fmt.Errorf("unable to setup controller, %w", err)
+	}
+
 	return nil
 }
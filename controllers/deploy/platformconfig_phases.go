@@ -42,6 +42,31 @@ func (r *PlatformConfigReconciler) InitializePhases() {
 		phases.WithResourceOptions(phases.ResourceOptionWithWait),
 	)
 
+	r.Phases.Register(
+		"Trust-Injection",
+		trustInjectionPhase,
+		phases.CreateEvent,
+	)
+
+	r.Phases.Register(
+		"Role-Bindings",
+		roleBindingsPhase,
+		phases.CreateEvent,
+	)
+
+	r.Phases.Register(
+		"Proxy-Injection",
+		proxyInjectionPhase,
+		phases.CreateEvent,
+	)
+
+	r.Phases.Register(
+		"Root-CA",
+		rootCAPhase,
+		phases.CreateEvent,
+		phases.WithCustomRequeueResult(ctrl.Result{RequeueAfter: 5 * time.Second}),
+	)
+
 	r.Phases.Register(
 		"Check-Ready",
 		phases.CheckReadyPhase,
@@ -51,7 +76,7 @@ func (r *PlatformConfigReconciler) InitializePhases() {
 
 	r.Phases.Register(
 		"Complete",
-		phases.CompletePhase,
+		completePhase,
 		phases.CreateEvent,
 	)
 
@@ -69,6 +94,31 @@ func (r *PlatformConfigReconciler) InitializePhases() {
 		phases.UpdateEvent,
 	)
 
+	r.Phases.Register(
+		"Trust-Injection",
+		trustInjectionPhase,
+		phases.UpdateEvent,
+	)
+
+	r.Phases.Register(
+		"Role-Bindings",
+		roleBindingsPhase,
+		phases.UpdateEvent,
+	)
+
+	r.Phases.Register(
+		"Proxy-Injection",
+		proxyInjectionPhase,
+		phases.UpdateEvent,
+	)
+
+	r.Phases.Register(
+		"Root-CA",
+		rootCAPhase,
+		phases.UpdateEvent,
+		phases.WithCustomRequeueResult(ctrl.Result{RequeueAfter: 5 * time.Second}),
+	)
+
 	r.Phases.Register(
 		"Check-Ready",
 		phases.CheckReadyPhase,
@@ -78,11 +128,25 @@ func (r *PlatformConfigReconciler) InitializePhases() {
 
 	r.Phases.Register(
 		"Complete",
-		phases.CompletePhase,
+		completePhase,
 		phases.UpdateEvent,
 	)
 
 	// Delete Phases
+	r.Phases.Register(
+		"Trust-Ejection",
+		trustEjectionPhase,
+		phases.DeleteEvent,
+		phases.WithCustomRequeueResult(ctrl.Result{RequeueAfter: 5 * time.Second}),
+	)
+
+	r.Phases.Register(
+		"Role-Unbinding",
+		roleUnbindingPhase,
+		phases.DeleteEvent,
+		phases.WithCustomRequeueResult(ctrl.Result{RequeueAfter: 5 * time.Second}),
+	)
+
 	r.Phases.Register(
 		"DeletionComplete",
 		phases.DeletionCompletePhase,
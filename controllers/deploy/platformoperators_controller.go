@@ -31,10 +31,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
-	deployv1alpha1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1alpha1"
-	"github.com/tbd-paas/platform-config-operator/apis/deploy/v1alpha1/platformoperators"
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+	"github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1/platformoperators"
 	"github.com/tbd-paas/platform-config-operator/internal/dependencies"
+	"github.com/tbd-paas/platform-config-operator/internal/desired"
+	"github.com/tbd-paas/platform-config-operator/internal/metrics"
 	"github.com/tbd-paas/platform-config-operator/internal/mutate"
+	"github.com/tbd-paas/platform-config-operator/internal/prune"
+	"github.com/tbd-paas/platform-config-operator/internal/watches"
 )
 
 // PlatformOperatorsReconciler reconciles a PlatformOperators object.
@@ -48,6 +52,7 @@ type PlatformOperatorsReconciler struct {
 	Watches      []client.Object
 	Phases       *phases.Registry
 	Manager      manager.Manager
+	Desired      *desired.Cache
 }
 
 func NewPlatformOperatorsReconciler(mgr ctrl.Manager) *PlatformOperatorsReconciler {
@@ -60,6 +65,7 @@ func NewPlatformOperatorsReconciler(mgr ctrl.Manager) *PlatformOperatorsReconcil
 		Watches:      []client.Object{},
 		Phases:       &phases.Registry{},
 		Manager:      mgr,
+		Desired:      &desired.Cache{},
 	}
 }
 
@@ -85,16 +91,48 @@ func (r *PlatformOperatorsReconciler) Reconcile(ctx context.Context, request ctr
 		return ctrl.Result{}, nil
 	}
 
+	// the child resources are generated once for the whole reconciliation
+	defer r.Desired.Forget(req)
+
 	if err := phases.RegisterDeleteHooks(r, req); err != nil {
 		return ctrl.Result{}, err
 	}
 
+	// a workload which is being deleted only runs its delete phases, so that a failure to generate
+	// or prune its child resources does not keep its finalizer from being removed
+	if !req.Workload.GetDeletionTimestamp().IsZero() {
+		return r.Phases.HandleExecution(r, req)
+	}
+
+	// watch the kinds of all child resources so that drift is corrected as it happens rather
+	// than on the next requeue
+	children, err := r.GetResources(req)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := watches.Children(r, req, children); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// the certificates of the metrics endpoints are created once the certificates capability is ready
+	if err := watches.Capabilities(r, req, func() client.ObjectList {
+		return &deployv1beta1.PlatformOperatorsList{}
+	}, metrics.CertManagerGVK); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// remove any child resources which were previously persisted but are no longer desired
+	if err := prune.Orphans(r, req, children); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// execute the phases
 	return r.Phases.HandleExecution(r, req)
 }
 
 func (r *PlatformOperatorsReconciler) NewRequest(ctx context.Context, request ctrl.Request) (*workload.Request, error) {
-	component := &deployv1alpha1.PlatformOperators{}
+	component := &deployv1beta1.PlatformOperators{}
 
 	log := r.Log.WithValues(
 		"kind", component.GetWorkloadGVK().Kind,
@@ -123,14 +161,17 @@ func (r *PlatformOperatorsReconciler) NewRequest(ctx context.Context, request ct
 	return workloadRequest, nil
 }
 
-// GetResources resources runs the methods to properly construct the resources in memory.
+// GetResources resources runs the methods to properly construct the resources in memory, once per
+// reconciliation.
 func (r *PlatformOperatorsReconciler) GetResources(req *workload.Request) ([]client.Object, error) {
-	component, err := platformoperators.ConvertWorkload(req.Workload)
-	if err != nil {
-		return nil, err
-	}
+	return r.Desired.Get(req, func() ([]client.Object, error) {
+		component, err := platformoperators.ConvertWorkload(req.Workload)
+		if err != nil {
+			return nil, err
+		}
 
-	return platformoperators.Generate(*component, r, req)
+		return platformoperators.Generate(*component, r, req)
+	})
 }
 
 // GetEventRecorder returns the event recorder for writing kubernetes events.
@@ -192,7 +233,7 @@ func (r *PlatformOperatorsReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 	baseController, err := ctrl.NewControllerManagedBy(mgr).
 		WithEventFilter(predicates.WorkloadPredicates()).
-		For(&deployv1alpha1.PlatformOperators{}).
+		For(&deployv1beta1.PlatformOperators{}).
 		Build(r)
 	if err != nil {
 		return fmt.Errorf("unable to setup controller, %w", err)
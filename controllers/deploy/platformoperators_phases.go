@@ -50,10 +50,17 @@ func (r *PlatformOperatorsReconciler) InitializePhases() {
 
 	r.Phases.Register(
 		"Complete",
-		phases.CompletePhase,
+		completePhase,
 		phases.CreateEvent,
 	)
 
+	r.Phases.Register(
+		"Metrics-Certificates",
+		metricsCertificatesPhase,
+		phases.CreateEvent,
+		phases.WithCustomRequeueResult(ctrl.Result{RequeueAfter: time.Minute}),
+	)
+
 	// Update Phases
 	r.Phases.Register(
 		"Dependency",
@@ -77,8 +84,15 @@ func (r *PlatformOperatorsReconciler) InitializePhases() {
 
 	r.Phases.Register(
 		"Complete",
-		phases.CompletePhase,
+		completePhase,
+		phases.UpdateEvent,
+	)
+
+	r.Phases.Register(
+		"Metrics-Certificates",
+		metricsCertificatesPhase,
 		phases.UpdateEvent,
+		phases.WithCustomRequeueResult(ctrl.Result{RequeueAfter: time.Minute}),
 	)
 
 	// Delete Phases
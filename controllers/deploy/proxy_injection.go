@@ -0,0 +1,63 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"github.com/nukleros/operator-builder-tools/pkg/controller/phases"
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+
+	"github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1/platformconfig"
+	"github.com/tbd-paas/platform-config-operator/internal/disruption"
+	"github.com/tbd-paas/platform-config-operator/internal/proxy"
+)
+
+// proxyInjectionPhase injects the proxy and the additional environment variables into the
+// Deployments which the capability operators create, as the custom resources of the capabilities do
+// not carry an environment.
+func proxyInjectionPhase(r workload.Reconciler, req *workload.Request, options ...phases.ResourceOption) (bool, error) {
+	component, err := platformconfig.ConvertWorkload(req.Workload)
+	if err != nil {
+		return false, err
+	}
+
+	proxySpec, err := proxy.Resolve(r, req, component.Spec.Proxy)
+	if err != nil {
+		return false, err
+	}
+
+	namespaces := []string{}
+
+	if component.Spec.Certificates.Enabled {
+		namespaces = append(namespaces, component.Spec.Certificates.Namespace)
+	}
+
+	if component.Spec.Identity.Enabled {
+		namespaces = append(namespaces, component.Spec.Identity.Namespace)
+	}
+
+	if err := proxy.Inject(
+		r,
+		req,
+		namespaces,
+		disruption.IsCapabilityDeployment,
+		proxy.Variables(proxySpec, component.Spec.ExtraEnv),
+	); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
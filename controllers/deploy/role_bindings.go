@@ -0,0 +1,60 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"github.com/nukleros/operator-builder-tools/pkg/controller/phases"
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+
+	"github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1/platformconfig"
+	"github.com/tbd-paas/platform-config-operator/internal/rolebindings"
+)
+
+// roleBindingsPhase annotates the service accounts which are selected by the role bindings of the
+// identity capability with the roles which they assume, and removes the annotations which the
+// operator set from those which are no longer selected.
+func roleBindingsPhase(r workload.Reconciler, req *workload.Request, options ...phases.ResourceOption) (bool, error) {
+	component, err := platformconfig.ConvertWorkload(req.Workload)
+	if err != nil {
+		return false, err
+	}
+
+	statuses, err := rolebindings.Bind(
+		r,
+		req,
+		component.Spec.Identity.RoleBindings,
+		component.Spec.Cloud.AWS.AccountID,
+		component.Spec.Identity.Enabled && component.Spec.Cloud.Type == "aws",
+	)
+	if err != nil {
+		return false, err
+	}
+
+	component.SetRoleBindingStatuses(statuses)
+
+	return true, nil
+}
+
+// roleUnbindingPhase removes the annotations which the operator set from the service accounts when
+// a PlatformConfig is deleted, as the service accounts are not child resources and outlive it.
+func roleUnbindingPhase(r workload.Reconciler, req *workload.Request, options ...phases.ResourceOption) (bool, error) {
+	if err := rolebindings.Unbind(r, req); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"github.com/nukleros/operator-builder-tools/pkg/controller/phases"
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+
+	"github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1/platformconfig"
+	"github.com/tbd-paas/platform-config-operator/internal/issuers"
+	"github.com/tbd-paas/platform-config-operator/internal/rootca"
+)
+
+// rootCAPhase generates and rotates the private root CA of a local cloud once the child resources,
+// and with them the certificates namespace, are created.  The root CA is stored in a secret which is
+// not a child resource, so that private keys are neither rendered nor written to manifests by the
+// CLI.  A rotation which waits upon the reissuance of certificates progresses as their status
+// changes, as the Certificates which are issued from the root CA are watched.
+func rootCAPhase(r workload.Reconciler, req *workload.Request, options ...phases.ResourceOption) (bool, error) {
+	component, err := platformconfig.ConvertWorkload(req.Workload)
+	if err != nil {
+		return false, err
+	}
+
+	if !component.Spec.Certificates.Enabled || !component.Spec.Cloud.Local {
+		component.SetRootCAStatus(nil)
+
+		return true, nil
+	}
+
+	// the certificates namespace exists once the CertManager resource is ready
+	ready, err := issuers.Ready(r, req)
+	if err != nil {
+		return false, err
+	}
+
+	if !ready {
+		return false, nil
+	}
+
+	rootCAStatus, err := rootca.Ensure(r, req, component.Spec.Certificates.Namespace, component.Spec.Certificates.RootCA)
+	if err != nil {
+		return false, err
+	}
+
+	component.SetRootCAStatus(rootCAStatus)
+
+	return true, nil
+}
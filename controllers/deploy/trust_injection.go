@@ -0,0 +1,61 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploy
+
+import (
+	"github.com/nukleros/operator-builder-tools/pkg/controller/phases"
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+
+	"github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1/platformconfig"
+	"github.com/tbd-paas/platform-config-operator/internal/trust"
+)
+
+// trustInjectionPhase labels the namespaces which are selected for injection, which receive the
+// Bundles that do not select namespaces themselves, and removes the label from those which are not.
+// The namespaces are labeled once the child resources, including the namespaces of the capabilities,
+// are created.
+func trustInjectionPhase(r workload.Reconciler, req *workload.Request, options ...phases.ResourceOption) (bool, error) {
+	component, err := platformconfig.ConvertWorkload(req.Workload)
+	if err != nil {
+		return false, err
+	}
+
+	injected, err := trust.Inject(
+		r,
+		req,
+		component.Spec.Certificates.Trust.Injection,
+		component.Spec.Certificates.Enabled && trust.Injected(component.Spec.Certificates.Trust.Bundles),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	component.SetInjectedNamespaces(injected)
+
+	return true, nil
+}
+
+// trustEjectionPhase removes the label of the namespaces which are selected for injection when a
+// PlatformConfig is deleted, as the namespaces, such as those of the platform operators and of
+// tenants, are not child resources and outlive it.
+func trustEjectionPhase(r workload.Reconciler, req *workload.Request, options ...phases.ResourceOption) (bool, error) {
+	if err := trust.Eject(r, req); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,320 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package budgets
+
+import (
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+)
+
+// +kubebuilder:rbac:groups=core,resources=resourcequotas,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=limitranges,verbs=get;list;watch;create;update;patch;delete
+
+const (
+	SizeSmall  = "small"
+	SizeMedium = "medium"
+	SizeLarge  = "large"
+)
+
+var ErrUnexpectedObject = errors.New("unexpected capability object")
+
+// surgePercent is the default maximum surge of the rolling update of a Deployment, during which
+// the surged pods count against the quota in addition to the desired replicas.
+const surgePercent = 25
+
+// profiles are the default resources of the containers of a capability namespace for each
+// deployment size.
+var profiles = map[string]deployv1beta1.ResourceBudgetDefaults{
+	SizeSmall: {
+		RequestCPU:    "25m",
+		RequestMemory: "32Mi",
+		LimitCPU:      "250m",
+		LimitMemory:   "128Mi",
+	},
+	SizeMedium: {
+		RequestCPU:    "50m",
+		RequestMemory: "64Mi",
+		LimitCPU:      "500m",
+		LimitMemory:   "256Mi",
+	},
+	SizeLarge: {
+		RequestCPU:    "100m",
+		RequestMemory: "128Mi",
+		LimitCPU:      "1",
+		LimitMemory:   "512Mi",
+	},
+}
+
+// Component is a Deployment of a capability, with a single container, whose pods are accounted for
+// by the quota of its namespace.
+type Component struct {
+	// Replicas is the maximum number of replicas of the component.
+	Replicas int
+
+	// Requests and Limits are the resources of the container of the component.  Resources which are
+	// not set are assigned by the LimitRange of the namespace.
+	Requests map[string]string
+	Limits   map[string]string
+}
+
+// Components reads the components from the blocks of capability custom resources at a set of
+// paths, each of which contains the replicas and resources of a component.  The replicas default to
+// one when they are not set.
+func Components(objects []client.Object, paths ...[]string) ([]Component, error) {
+	components := []Component{}
+
+	for _, object := range objects {
+		capability, ok := object.(*unstructured.Unstructured)
+		if !ok {
+			return nil, fmt.Errorf("%w, %T", ErrUnexpectedObject, object)
+		}
+
+		for _, path := range paths {
+			component, err := componentOf(capability, path...)
+			if err != nil {
+				return nil, err
+			}
+
+			components = append(components, component)
+		}
+	}
+
+	return components, nil
+}
+
+// componentOf reads a component from the block of a capability custom resource at a path.
+func componentOf(object *unstructured.Unstructured, path ...string) (Component, error) {
+	fields := append([]string{}, path...)
+
+	replicas, _, err := unstructured.NestedFieldNoCopy(object.Object, append(fields, "replicas")...)
+	if err != nil {
+		return Component{}, fmt.Errorf("unable to read replicas of %s, %w", object.GetKind(), err)
+	}
+
+	component := Component{Replicas: 1}
+
+	switch replicas := replicas.(type) {
+	case int:
+		component.Replicas = replicas
+	case int64:
+		component.Replicas = int(replicas)
+	}
+
+	requests, _, err := unstructured.NestedFieldNoCopy(object.Object, append(fields, "resources", "requests")...)
+	if err != nil {
+		return Component{}, fmt.Errorf("unable to read resource requests of %s, %w", object.GetKind(), err)
+	}
+
+	limits, _, err := unstructured.NestedFieldNoCopy(object.Object, append(fields, "resources", "limits")...)
+	if err != nil {
+		return Component{}, fmt.Errorf("unable to read resource limits of %s, %w", object.GetKind(), err)
+	}
+
+	component.Requests = stringMap(requests)
+	component.Limits = stringMap(limits)
+
+	return component, nil
+}
+
+// stringMap returns the string values of an unstructured map.
+func stringMap(value interface{}) map[string]string {
+	values, _ := value.(map[string]interface{})
+
+	out := make(map[string]string, len(values))
+
+	for key, value := range values {
+		if value, ok := value.(string); ok {
+			out[key] = value
+		}
+	}
+
+	return out
+}
+
+// Resolve returns the resource budget of a namespace which runs a set of components for a
+// deployment size, with any overrides applied.  The quota is derived from the pods which the
+// components run at most, which includes the surge of a rolling update of each of them, so that the
+// quota never blocks a rollout.  An unknown deployment size resolves to the small profile.
+func Resolve(size string, overrides deployv1beta1.ResourceBudget, components []Component) (deployv1beta1.ResourceBudget, error) {
+	defaults, ok := profiles[size]
+	if !ok {
+		defaults = profiles[SizeSmall]
+	}
+
+	override(&defaults.RequestCPU, overrides.Defaults.RequestCPU)
+	override(&defaults.RequestMemory, overrides.Defaults.RequestMemory)
+	override(&defaults.LimitCPU, overrides.Defaults.LimitCPU)
+	override(&defaults.LimitMemory, overrides.Defaults.LimitMemory)
+
+	totals := map[string]*resource.Quantity{}
+	pods := 0
+
+	for _, component := range components {
+		count := component.Replicas + (component.Replicas*surgePercent+99)/100
+		pods += count
+
+		for key, quantities := range map[string][3]string{
+			"requests.cpu":    {component.Requests["cpu"], component.Limits["cpu"], defaults.RequestCPU},
+			"requests.memory": {component.Requests["memory"], component.Limits["memory"], defaults.RequestMemory},
+			"limits.cpu":      {component.Limits["cpu"], defaults.LimitCPU, ""},
+			"limits.memory":   {component.Limits["memory"], defaults.LimitMemory, ""},
+		} {
+			// the first quantity which is set is assigned to the container, as an unset request
+			// defaults to the limit and an unset limit defaults to that of the LimitRange
+			value := quantities[0]
+			for i := 1; value == "" && i < len(quantities); i++ {
+				value = quantities[i]
+			}
+
+			quantity, err := resource.ParseQuantity(value)
+			if err != nil {
+				return deployv1beta1.ResourceBudget{}, fmt.Errorf("invalid %s quantity %q, %w", key, value, err)
+			}
+
+			if totals[key] == nil {
+				totals[key] = resource.NewQuantity(0, quantity.Format)
+			}
+
+			for i := 0; i < count; i++ {
+				totals[key].Add(quantity)
+			}
+		}
+	}
+
+	budget := deployv1beta1.ResourceBudget{
+		Quota: deployv1beta1.ResourceBudgetQuota{
+			RequestsCPU:    total(totals["requests.cpu"]),
+			RequestsMemory: total(totals["requests.memory"]),
+			LimitsCPU:      total(totals["limits.cpu"]),
+			LimitsMemory:   total(totals["limits.memory"]),
+			Pods:           pods,
+		},
+		Defaults: defaults,
+	}
+
+	override(&budget.Quota.RequestsCPU, overrides.Quota.RequestsCPU)
+	override(&budget.Quota.RequestsMemory, overrides.Quota.RequestsMemory)
+	override(&budget.Quota.LimitsCPU, overrides.Quota.LimitsCPU)
+	override(&budget.Quota.LimitsMemory, overrides.Quota.LimitsMemory)
+
+	if overrides.Quota.Pods > 0 {
+		budget.Quota.Pods = overrides.Quota.Pods
+	}
+
+	return budget, nil
+}
+
+// Generate returns the ResourceQuota and LimitRange which enforce the resource budget of a
+// namespace which runs a set of components for a deployment size.
+func Generate(
+	namespace string,
+	labels map[string]interface{},
+	size string,
+	overrides deployv1beta1.ResourceBudget,
+	components []Component,
+) ([]client.Object, error) {
+	if namespace == "" {
+		return []client.Object{}, nil
+	}
+
+	budget, err := Resolve(size, overrides, components)
+	if err != nil {
+		return nil, err
+	}
+
+	return []client.Object{
+		&unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ResourceQuota",
+				"metadata": map[string]interface{}{
+					"name":      "platform-quota",
+					"namespace": namespace,
+					"labels":    copyLabels(labels),
+				},
+				"spec": map[string]interface{}{
+					"hard": map[string]interface{}{
+						"requests.cpu":    budget.Quota.RequestsCPU,
+						"requests.memory": budget.Quota.RequestsMemory,
+						"limits.cpu":      budget.Quota.LimitsCPU,
+						"limits.memory":   budget.Quota.LimitsMemory,
+						"pods":            budget.Quota.Pods,
+					},
+				},
+			},
+		},
+		&unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "LimitRange",
+				"metadata": map[string]interface{}{
+					"name":      "platform-limits",
+					"namespace": namespace,
+					"labels":    copyLabels(labels),
+				},
+				"spec": map[string]interface{}{
+					"limits": []interface{}{
+						map[string]interface{}{
+							"type": "Container",
+							"defaultRequest": map[string]interface{}{
+								"cpu":    budget.Defaults.RequestCPU,
+								"memory": budget.Defaults.RequestMemory,
+							},
+							"default": map[string]interface{}{
+								"cpu":    budget.Defaults.LimitCPU,
+								"memory": budget.Defaults.LimitMemory,
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// total returns the string form of a total quantity, which is zero when nothing was added to it.
+func total(quantity *resource.Quantity) string {
+	if quantity == nil {
+		return "0"
+	}
+
+	return quantity.String()
+}
+
+// override sets a value to its override, if one is provided.
+func override(value *string, override string) {
+	if override != "" {
+		*value = override
+	}
+}
+
+// copyLabels returns a copy of a set of labels, so that mutating the labels of one object does
+// not mutate those of another.
+func copyLabels(labels map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(labels))
+	for key, value := range labels {
+		copied[key] = value
+	}
+
+	return copied
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package budgets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+)
+
+func TestResolveFitsComponents(t *testing.T) {
+	t.Parallel()
+
+	for _, size := range []string{SizeSmall, SizeMedium, SizeLarge} {
+		size := size
+
+		t.Run(size, func(t *testing.T) {
+			t.Parallel()
+
+			components := []Component{
+				{
+					Replicas: 3,
+					Requests: map[string]string{"cpu": "50m", "memory": "64Mi"},
+					Limits:   map[string]string{"memory": "128Mi"},
+				},
+				{Replicas: 2},
+			}
+
+			budget, err := Resolve(size, deployv1beta1.ResourceBudget{}, components)
+			require.NoError(t, err)
+
+			// every pod of a rollout of every component, with the defaults of the LimitRange
+			// assigned to its container, must fit in the quota
+			pods := 0
+			requestsCPU := resource.MustParse("0")
+			limitsMemory := resource.MustParse("0")
+
+			for _, component := range components {
+				count := component.Replicas + (component.Replicas*surgePercent+99)/100
+				pods += count
+
+				for i := 0; i < count; i++ {
+					requestsCPU.Add(resource.MustParse(first(component.Requests["cpu"], component.Limits["cpu"], budget.Defaults.RequestCPU)))
+					limitsMemory.Add(resource.MustParse(first(component.Limits["memory"], budget.Defaults.LimitMemory)))
+				}
+			}
+
+			require.Equal(t, profiles[size], budget.Defaults)
+			require.Equal(t, pods, budget.Quota.Pods)
+			require.Equal(t, 0, requestsCPU.Cmp(resource.MustParse(budget.Quota.RequestsCPU)))
+			require.Equal(t, 0, limitsMemory.Cmp(resource.MustParse(budget.Quota.LimitsMemory)))
+		})
+	}
+}
+
+func TestResolveOverrides(t *testing.T) {
+	t.Parallel()
+
+	overrides := deployv1beta1.ResourceBudget{
+		Quota: deployv1beta1.ResourceBudgetQuota{RequestsCPU: "4", Pods: 40},
+		Defaults: deployv1beta1.ResourceBudgetDefaults{
+			LimitMemory: "1Gi",
+		},
+	}
+
+	budget, err := Resolve("unknown", overrides, []Component{{Replicas: 1}})
+	require.NoError(t, err)
+
+	require.Equal(t, "4", budget.Quota.RequestsCPU)
+	require.Equal(t, 40, budget.Quota.Pods)
+	require.Equal(t, "1Gi", budget.Defaults.LimitMemory)
+	require.Equal(t, profiles[SizeSmall].RequestCPU, budget.Defaults.RequestCPU)
+
+	// the derived quota accounts for the overridden defaults
+	require.Equal(t, "2Gi", budget.Quota.LimitsMemory)
+}
+
+func TestResolveInvalidQuantity(t *testing.T) {
+	t.Parallel()
+
+	_, err := Resolve(SizeSmall, deployv1beta1.ResourceBudget{}, []Component{
+		{Replicas: 1, Requests: map[string]string{"cpu": "lots"}},
+	})
+	require.Error(t, err)
+}
+
+func TestComponents(t *testing.T) {
+	t.Parallel()
+
+	capability := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"kind": "CertManager",
+			"spec": map[string]interface{}{
+				"controller": map[string]interface{}{
+					"replicas": 2,
+					"resources": map[string]interface{}{
+						"requests": map[string]interface{}{"cpu": "25m"},
+						"limits":   map[string]interface{}{"memory": "64Mi"},
+					},
+				},
+				"webhook": map[string]interface{}{},
+			},
+		},
+	}
+
+	components, err := Components(
+		[]client.Object{capability},
+		[]string{"spec", "controller"},
+		[]string{"spec", "webhook"},
+	)
+	require.NoError(t, err)
+	require.Equal(t, []Component{
+		{
+			Replicas: 2,
+			Requests: map[string]string{"cpu": "25m"},
+			Limits:   map[string]string{"memory": "64Mi"},
+		},
+		{Replicas: 1, Requests: map[string]string{}, Limits: map[string]string{}},
+	}, components)
+
+	_, err = Components([]client.Object{&deployv1beta1.PlatformConfig{}}, []string{"spec"})
+	require.ErrorIs(t, err, ErrUnexpectedObject)
+}
+
+func first(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
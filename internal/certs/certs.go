@@ -0,0 +1,496 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certs
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch;update;patch
+
+const (
+	// CACertKey is the key in the secret which stores the CA certificates which are trusted, the
+	// first of which is the CA which issues the serving certificate.
+	CACertKey = "ca.crt"
+
+	// CAKeyKey is the key in the secret which stores the CA private key.
+	CAKeyKey = "ca.key"
+
+	// ConversionPath is the path at which the conversion webhook is served.
+	ConversionPath = "/convert"
+
+	caValidity      = 10 * 365 * 24 * time.Hour
+	servingValidity = 365 * 24 * time.Hour
+	renewBefore     = 30 * 24 * time.Hour
+
+	// renewInterval is the interval at which the serving certificate is checked for renewal.
+	renewInterval = time.Hour
+)
+
+var (
+	ErrMissingNamespace = errors.New("namespace for the webhook service is required")
+	ErrInvalidCA        = errors.New("invalid CA")
+)
+
+// Options are the options used to ensure the serving certificate for the webhook server.
+type Options struct {
+	// Namespace is the namespace of both the webhook service and the secret storing the certificate.
+	Namespace string
+
+	// ServiceName is the name of the service which fronts the webhook server.
+	ServiceName string
+
+	// SecretName is the name of the secret which stores the certificate.
+	SecretName string
+
+	// CertDir is the directory that the webhook server reads its certificate from.
+	CertDir string
+
+	// CRDNames are the names of the custom resource definitions which are converted by the
+	// conversion webhook.
+	CRDNames []string
+}
+
+// Ensure ensures that a valid serving certificate for the webhook server exists.  The operator
+// installs cert-manager itself, so it cannot rely on it to issue its own certificate.  Instead, a
+// self-signed CA and a serving certificate for the webhook service are generated and stored in a
+// secret which is shared between replicas, written to the certificate directory of the webhook
+// server and injected into the conversion configuration of the custom resource definitions.  The
+// serving certificate is reissued by the same CA when it is close to expiring, so that the
+// certificates which other replicas serve remain trusted.  Ensure is called at startup, and then
+// at an interval by a Renewer.
+func Ensure(ctx context.Context, c client.Client, opts Options) error {
+	if opts.Namespace == "" {
+		return ErrMissingNamespace
+	}
+
+	secret, err := ensureSecret(ctx, c, opts)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(opts.CertDir, 0o700); err != nil {
+		return fmt.Errorf("unable to create certificate directory %s, %w", opts.CertDir, err)
+	}
+
+	for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey} {
+		if err := writeFile(filepath.Join(opts.CertDir, key), secret.Data[key]); err != nil {
+			return fmt.Errorf("unable to write %s to certificate directory, %w", key, err)
+		}
+	}
+
+	for _, name := range opts.CRDNames {
+		if err := injectConversion(ctx, c, name, opts, secret.Data[CACertKey]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Renewer ensures the serving certificate of the webhook server at an interval, so that it is
+// renewed before it expires.  Every replica runs a Renewer, as each writes the certificate which
+// is stored in the shared secret to its own certificate directory, which the webhook server
+// reloads as it changes.
+type Renewer struct {
+	client client.Client
+	opts   Options
+	log    logr.Logger
+}
+
+// NewRenewer returns a Renewer of the serving certificate which is described by the options.
+func NewRenewer(c client.Client, opts Options, log logr.Logger) *Renewer {
+	return &Renewer{client: c, opts: opts, log: log}
+}
+
+// Start ensures the serving certificate at an interval until the context is cancelled.
+func (r *Renewer) Start(ctx context.Context) error {
+	ticker := time.NewTicker(renewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := Ensure(ctx, r.client, r.opts); err != nil {
+				r.log.Error(err, "unable to renew webhook certificate, continuing to serve the previous certificate")
+			}
+		}
+	}
+}
+
+// NeedLeaderElection determines if the Renewer only runs on the leader, which it does not, as every
+// replica writes the certificate to its own certificate directory.
+func (r *Renewer) NeedLeaderElection() bool {
+	return false
+}
+
+// ensureSecret returns the secret storing a valid serving certificate, creating or renewing it
+// as necessary.
+func ensureSecret(ctx context.Context, c client.Client, opts Options) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+
+	err := c.Get(ctx, client.ObjectKey{Namespace: opts.Namespace, Name: opts.SecretName}, secret)
+	if err != nil && !apierrs.IsNotFound(err) {
+		return nil, fmt.Errorf("unable to retrieve webhook certificate secret, %w", err)
+	}
+
+	exists := err == nil
+	if exists && isValid(secret.Data, opts) {
+		return secret, nil
+	}
+
+	var current map[string][]byte
+	if exists {
+		current = secret.Data
+	}
+
+	data, err := generate(current, opts, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      opts.SecretName,
+				Namespace: opts.Namespace,
+			},
+			Type: corev1.SecretTypeTLS,
+			Data: data,
+		}
+
+		if err := c.Create(ctx, secret); err != nil {
+			if !apierrs.IsAlreadyExists(err) {
+				return nil, fmt.Errorf("unable to create webhook certificate secret, %w", err)
+			}
+
+			// another replica won the race to create the certificate, so we use theirs.
+			return ensureSecret(ctx, c, opts)
+		}
+
+		return secret, nil
+	}
+
+	secret.Data = data
+	if err := c.Update(ctx, secret); err != nil {
+		if !apierrs.IsConflict(err) {
+			return nil, fmt.Errorf("unable to update webhook certificate secret, %w", err)
+		}
+
+		// another replica won the race to renew the certificate, so we use theirs.
+		return ensureSecret(ctx, c, opts)
+	}
+
+	return secret, nil
+}
+
+// isValid determines if the serving certificate of a secret exists, is issued by its CA for the
+// webhook service and is not close to expiring.
+func isValid(data map[string][]byte, opts Options) bool {
+	caCert, _, err := parseCA(data)
+	if err != nil || !isCurrent(caCert, time.Now()) {
+		return false
+	}
+
+	cert, err := parseCertificate(data[corev1.TLSCertKey])
+	if err != nil || !isCurrent(cert, time.Now()) {
+		return false
+	}
+
+	if err := cert.CheckSignatureFrom(caCert); err != nil {
+		return false
+	}
+
+	return cert.VerifyHostname(dnsName(opts)) == nil
+}
+
+// isCurrent determines if a certificate is not close to expiring.
+func isCurrent(cert *x509.Certificate, now time.Time) bool {
+	return now.Add(renewBefore).Before(cert.NotAfter)
+}
+
+// generate issues a serving certificate for the webhook service.  The CA of the current data issues
+// it, unless the CA is missing or close to expiring, in which case a new CA is generated.  The
+// previous CA remains trusted until it expires, so that the certificates which it issued to other
+// replicas are trusted until those replicas load the reissued certificate.
+func generate(current map[string][]byte, opts Options, now time.Time) (map[string][]byte, error) {
+	caCert, caKey, err := parseCA(current)
+
+	caPEM := trusted(current[CACertKey], now)
+	caKeyPEM := current[CAKeyKey]
+
+	if err != nil || !isCurrent(caCert, now) {
+		var newCAPEM []byte
+
+		caCert, caKey, newCAPEM, caKeyPEM, err = generateCA(opts, now, caValidity)
+		if err != nil {
+			return nil, err
+		}
+
+		caPEM = append(newCAPEM, caPEM...)
+	}
+
+	certPEM, keyPEM, err := issue(opts, caCert, caKey, now, servingValidity)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string][]byte{
+		CACertKey:               caPEM,
+		CAKeyKey:                caKeyPEM,
+		corev1.TLSCertKey:       certPEM,
+		corev1.TLSPrivateKeyKey: keyPEM,
+	}, nil
+}
+
+// generateCA generates a self-signed CA, returning the parsed certificate and key along with their
+// PEM encodings.
+func generateCA(
+	opts Options,
+	now time.Time,
+	validity time.Duration,
+) (*x509.Certificate, *ecdsa.PrivateKey, []byte, []byte, error) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("unable to generate CA private key, %w", err)
+	}
+
+	caTemplate := &x509.Certificate{
+		SerialNumber:          serialNumber(),
+		Subject:               pkix.Name{CommonName: fmt.Sprintf("%s-ca", opts.ServiceName)},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("unable to create CA certificate, %w", err)
+	}
+
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("unable to parse CA certificate, %w", err)
+	}
+
+	caKeyDER, err := x509.MarshalECPrivateKey(caKey)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("unable to marshal CA private key, %w", err)
+	}
+
+	return caCert,
+		caKey,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: caKeyDER}),
+		nil
+}
+
+// issue issues a serving certificate for the webhook service which is signed by the CA, returning
+// the PEM encoded certificate and key.
+func issue(
+	opts Options,
+	caCert *x509.Certificate,
+	caKey *ecdsa.PrivateKey,
+	now time.Time,
+	validity time.Duration,
+) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to generate serving private key, %w", err)
+	}
+
+	name := dnsName(opts)
+
+	template := &x509.Certificate{
+		SerialNumber: serialNumber(),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name, name + ".cluster.local"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(validity),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create serving certificate, %w", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to marshal serving private key, %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		nil
+}
+
+// dnsName returns the DNS name of the webhook service.
+func dnsName(opts Options) string {
+	return fmt.Sprintf("%s.%s.svc", opts.ServiceName, opts.Namespace)
+}
+
+// parseCA parses the CA which issues the serving certificate, being the first of the trusted CA
+// certificates, along with its private key.
+func parseCA(data map[string][]byte) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	caCert, err := parseCertificate(data[CACertKey])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	block, _ := pem.Decode(data[CAKeyKey])
+	if block == nil {
+		return nil, nil, fmt.Errorf("%w, missing private key", ErrInvalidCA)
+	}
+
+	caKey, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w, %w", ErrInvalidCA, err)
+	}
+
+	if !caKey.PublicKey.Equal(caCert.PublicKey) {
+		return nil, nil, fmt.Errorf("%w, private key does not match certificate", ErrInvalidCA)
+	}
+
+	return caCert, caKey, nil
+}
+
+// parseCertificate parses the first certificate of PEM encoded data.
+func parseCertificate(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, fmt.Errorf("%w, missing certificate", ErrInvalidCA)
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse certificate, %w", err)
+	}
+
+	return cert, nil
+}
+
+// trusted returns the PEM encoded certificates of a bundle which have not yet expired.
+func trusted(bundle []byte, now time.Time) []byte {
+	kept := []byte{}
+
+	for rest := bundle; len(rest) > 0; {
+		var block *pem.Block
+
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil || !now.Before(cert.NotAfter) {
+			continue
+		}
+
+		kept = append(kept, pem.EncodeToMemory(block)...)
+	}
+
+	return kept
+}
+
+// writeFile writes a file unless it already has the contents, so that the webhook server does not
+// reload a certificate which has not changed.
+func writeFile(path string, contents []byte) error {
+	existing, err := os.ReadFile(path)
+	if err == nil && bytes.Equal(existing, contents) {
+		return nil
+	}
+
+	return os.WriteFile(path, contents, 0o600)
+}
+
+// serialNumber returns a random serial number for a certificate.
+func serialNumber() *big.Int {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return big.NewInt(time.Now().UnixNano())
+	}
+
+	return serial
+}
+
+// injectConversion configures a custom resource definition to be converted by the conversion
+// webhook using the provided CA bundle.
+func injectConversion(ctx context.Context, c client.Client, name string, opts Options, caBundle []byte) error {
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := c.Get(ctx, client.ObjectKey{Name: name}, crd); err != nil {
+		return fmt.Errorf("unable to retrieve custom resource definition %s, %w", name, err)
+	}
+
+	conversion := crd.Spec.Conversion
+	if conversion != nil && conversion.Webhook != nil && conversion.Webhook.ClientConfig != nil &&
+		conversion.Webhook.ClientConfig.Service != nil &&
+		conversion.Webhook.ClientConfig.Service.Name == opts.ServiceName &&
+		conversion.Webhook.ClientConfig.Service.Namespace == opts.Namespace &&
+		bytes.Equal(conversion.Webhook.ClientConfig.CABundle, caBundle) {
+		return nil
+	}
+
+	path := ConversionPath
+
+	crd.Spec.Conversion = &apiextensionsv1.CustomResourceConversion{
+		Strategy: apiextensionsv1.WebhookConverter,
+		Webhook: &apiextensionsv1.WebhookConversion{
+			ClientConfig: &apiextensionsv1.WebhookClientConfig{
+				Service: &apiextensionsv1.ServiceReference{
+					Namespace: opts.Namespace,
+					Name:      opts.ServiceName,
+					Path:      &path,
+				},
+				CABundle: caBundle,
+			},
+			ConversionReviewVersions: []string{"v1"},
+		},
+	}
+
+	if err := c.Update(ctx, crd); err != nil {
+		return fmt.Errorf("unable to inject conversion configuration into custom resource definition %s, %w", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certs
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const crdName = "platformconfigs.deploy.platform.tbd.io"
+
+func testOptions(t *testing.T) Options {
+	t.Helper()
+
+	return Options{
+		Namespace:   "tbd-operators-system",
+		ServiceName: "webhook-service",
+		SecretName:  "webhook-server-cert",
+		CertDir:     t.TempDir(),
+		CRDNames:    []string{crdName},
+	}
+}
+
+// testData returns the data of a secret whose CA and serving certificate expire after the given
+// durations.
+func testData(t *testing.T, opts Options, caValidity, servingValidity time.Duration) map[string][]byte {
+	t.Helper()
+
+	now := time.Now()
+
+	caCert, caKey, caPEM, caKeyPEM, err := generateCA(opts, now, caValidity)
+	require.NoError(t, err)
+
+	certPEM, keyPEM, err := issue(opts, caCert, caKey, now, servingValidity)
+	require.NoError(t, err)
+
+	return map[string][]byte{
+		CACertKey:               caPEM,
+		CAKeyKey:                caKeyPEM,
+		corev1.TLSCertKey:       certPEM,
+		corev1.TLSPrivateKeyKey: keyPEM,
+	}
+}
+
+// bundle parses each certificate of a PEM encoded bundle.
+func bundle(t *testing.T, bundlePEM []byte) []*x509.Certificate {
+	t.Helper()
+
+	certs := []*x509.Certificate{}
+
+	for rest := bundlePEM; len(rest) > 0; {
+		var block *pem.Block
+
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		require.NoError(t, err)
+
+		certs = append(certs, cert)
+	}
+
+	return certs
+}
+
+func TestEnsure(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, apiextensionsv1.AddToScheme(scheme))
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: crdName}},
+	).Build()
+
+	opts := testOptions(t)
+	ctx := context.Background()
+
+	require.NoError(t, Ensure(ctx, c, opts))
+
+	secret := &corev1.Secret{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: opts.Namespace, Name: opts.SecretName}, secret))
+	require.True(t, isValid(secret.Data, opts))
+
+	// the serving certificate is written for the webhook server
+	for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey} {
+		contents, err := os.ReadFile(filepath.Join(opts.CertDir, key))
+		require.NoError(t, err)
+		require.Equal(t, secret.Data[key], contents)
+	}
+
+	// the CA is injected into the conversion configuration
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Name: crdName}, crd))
+	require.Equal(t, apiextensionsv1.WebhookConverter, crd.Spec.Conversion.Strategy)
+	require.Equal(t, secret.Data[CACertKey], crd.Spec.Conversion.Webhook.ClientConfig.CABundle)
+	require.Equal(t, opts.ServiceName, crd.Spec.Conversion.Webhook.ClientConfig.Service.Name)
+
+	// a valid certificate is kept
+	require.NoError(t, Ensure(ctx, c, opts))
+
+	kept := &corev1.Secret{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: opts.Namespace, Name: opts.SecretName}, kept))
+	require.Equal(t, secret.Data, kept.Data)
+
+	// a certificate which is close to expiring is renewed by the same CA
+	expiring := testData(t, opts, caValidity, time.Hour)
+	kept.Data = expiring
+	require.NoError(t, c.Update(ctx, kept))
+
+	require.NoError(t, Ensure(ctx, c, opts))
+
+	renewed := &corev1.Secret{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: opts.Namespace, Name: opts.SecretName}, renewed))
+	require.True(t, isValid(renewed.Data, opts))
+	require.NotEqual(t, expiring[corev1.TLSCertKey], renewed.Data[corev1.TLSCertKey])
+	require.Equal(t, expiring[CACertKey], renewed.Data[CACertKey])
+
+	contents, err := os.ReadFile(filepath.Join(opts.CertDir, corev1.TLSCertKey))
+	require.NoError(t, err)
+	require.Equal(t, renewed.Data[corev1.TLSCertKey], contents)
+}
+
+func TestIsValid(t *testing.T) {
+	t.Parallel()
+
+	opts := testOptions(t)
+
+	other := opts
+	other.ServiceName = "other-service"
+
+	foreign := testData(t, opts, caValidity, servingValidity)
+	mismatched := testData(t, opts, caValidity, servingValidity)
+	mismatched[CACertKey] = foreign[CACertKey]
+	mismatched[CAKeyKey] = foreign[CAKeyKey]
+	mismatched[corev1.TLSCertKey] = testData(t, opts, caValidity, servingValidity)[corev1.TLSCertKey]
+
+	for _, tt := range []struct {
+		name  string
+		data  map[string][]byte
+		opts  Options
+		valid bool
+	}{
+		{name: "valid", data: testData(t, opts, caValidity, servingValidity), opts: opts, valid: true},
+		{name: "missing", data: nil, opts: opts},
+		{name: "expiring certificate", data: testData(t, opts, caValidity, time.Hour), opts: opts},
+		{name: "expiring CA", data: testData(t, opts, time.Hour, time.Hour), opts: opts},
+		{name: "other service", data: testData(t, opts, caValidity, servingValidity), opts: other},
+		{name: "other CA", data: mismatched, opts: opts},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tt.valid, isValid(tt.data, tt.opts))
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	opts := testOptions(t)
+	now := time.Now()
+
+	expiredCA, _, expiredPEM, _, err := generateCA(opts, now.Add(-2*time.Hour), time.Hour)
+	require.NoError(t, err)
+	require.True(t, now.After(expiredCA.NotAfter))
+
+	current := testData(t, opts, caValidity, time.Hour)
+	expiring := testData(t, opts, time.Hour, time.Hour)
+
+	withExpired := testData(t, opts, time.Hour, time.Hour)
+	withExpired[CACertKey] = append(append([]byte{}, withExpired[CACertKey]...), expiredPEM...)
+
+	for _, tt := range []struct {
+		name string
+		data map[string][]byte
+
+		// keepsCA determines if the CA of the data issues the serving certificate
+		keepsCA bool
+
+		// trusted is the number of CA certificates which are trusted afterwards
+		trusted int
+	}{
+		{name: "missing", data: nil, trusted: 1},
+		{name: "current CA", data: current, keepsCA: true, trusted: 1},
+		{name: "expiring CA", data: expiring, trusted: 2},
+		{name: "expired CA", data: withExpired, trusted: 2},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := generate(tt.data, opts, now)
+			require.NoError(t, err)
+			require.True(t, isValid(data, opts))
+
+			trustedCAs := bundle(t, data[CACertKey])
+			require.Len(t, trustedCAs, tt.trusted)
+
+			if tt.keepsCA {
+				require.Equal(t, tt.data[CACertKey], data[CACertKey])
+				require.Equal(t, tt.data[CAKeyKey], data[CAKeyKey])
+
+				return
+			}
+
+			// a new CA issues the certificate, while a previous CA which has not expired remains
+			// trusted for the certificates it issued to other replicas
+			if tt.data != nil {
+				previous, err := parseCertificate(tt.data[CACertKey])
+				require.NoError(t, err)
+				require.NotEqual(t, previous.Raw, trustedCAs[0].Raw)
+				require.Equal(t, previous.Raw, trustedCAs[1].Raw)
+			}
+
+			for _, ca := range trustedCAs {
+				require.True(t, now.Before(ca.NotAfter))
+			}
+		})
+	}
+}
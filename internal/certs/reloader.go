@@ -0,0 +1,164 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certs
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	certutil "k8s.io/client-go/util/cert"
+)
+
+// reloadInterval is the interval at which the certificate of a Reloader is checked for changes.
+const reloadInterval = 30 * time.Second
+
+// Reloader serves the certificate which is mounted into a directory from a Secret, such as one
+// which is issued by cert-manager, and reloads it as the Secret is renewed.  A self-signed
+// certificate is served until the Secret is first mounted, so that the server may start before the
+// certificate is issued.  The files are polled, as the kubelet replaces the mounted files atomically
+// through a symbolic link which file notifications do not follow reliably.
+type Reloader struct {
+	certPath string
+	keyPath  string
+	log      logr.Logger
+
+	mutex    sync.RWMutex
+	current  *tls.Certificate
+	loaded   []byte
+	fallback *tls.Certificate
+}
+
+// NewReloader returns a Reloader of the certificate and key which are mounted into a directory.
+func NewReloader(certDir string, log logr.Logger) (*Reloader, error) {
+	certPEM, keyPEM, err := certutil.GenerateSelfSignedCertKey("localhost", []net.IP{net.ParseIP("127.0.0.1")}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate self-signed certificate, %w", err)
+	}
+
+	fallback, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse self-signed certificate, %w", err)
+	}
+
+	reloader := &Reloader{
+		certPath: filepath.Join(certDir, corev1.TLSCertKey),
+		keyPath:  filepath.Join(certDir, corev1.TLSPrivateKeyKey),
+		log:      log,
+		fallback: &fallback,
+	}
+
+	if err := reloader.load(); err != nil {
+		return nil, err
+	}
+
+	return reloader, nil
+}
+
+// GetCertificate returns the current certificate, for use as tls.Config.GetCertificate.
+func (r *Reloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if r.current == nil {
+		return r.fallback, nil
+	}
+
+	return r.current, nil
+}
+
+// TLSOpt returns an option which serves the certificate of the Reloader.
+func (r *Reloader) TLSOpt() func(*tls.Config) {
+	return func(config *tls.Config) {
+		config.GetCertificate = r.GetCertificate
+	}
+}
+
+// Start reloads the certificate at an interval until the context is cancelled.
+func (r *Reloader) Start(ctx context.Context) error {
+	ticker := time.NewTicker(reloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := r.load(); err != nil {
+				r.log.Error(err, "unable to reload certificate, continuing to serve the previous certificate")
+			}
+		}
+	}
+}
+
+// NeedLeaderElection determines if the Reloader only runs on the leader, which it does not, as every
+// replica serves its own certificate.
+func (r *Reloader) NeedLeaderElection() bool {
+	return false
+}
+
+// load loads the certificate and key if they are mounted and have changed since they were last
+// loaded.
+func (r *Reloader) load() error {
+	certPEM, err := os.ReadFile(r.certPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("unable to read certificate, %w", err)
+	}
+
+	keyPEM, err := os.ReadFile(r.keyPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("unable to read private key, %w", err)
+	}
+
+	contents := append(append([]byte{}, certPEM...), keyPEM...)
+
+	r.mutex.RLock()
+	unchanged := bytes.Equal(contents, r.loaded)
+	r.mutex.RUnlock()
+
+	if unchanged {
+		return nil
+	}
+
+	certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return fmt.Errorf("unable to parse certificate, %w", err)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.current = &certificate
+	r.loaded = contents
+
+	r.log.Info("loaded certificate", "path", r.certPath)
+
+	return nil
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package crdschema reads the schemas of the custom resource definitions of this operator.
+package crdschema
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/defaulting"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/pruning"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/tbd-paas/platform-config-operator/config/crd"
+)
+
+var ErrMissingSchema = errors.New("missing custom resource definition schema")
+
+// Schema returns the structural schema of a version of a custom resource definition of this
+// operator.
+func Schema(gvk schema.GroupVersionKind) (*structuralschema.Structural, error) {
+	files, err := fs.Glob(crd.Bases, "bases/*.yaml")
+	if err != nil {
+		return nil, fmt.Errorf("unable to list custom resource definitions, %w", err)
+	}
+
+	for _, file := range files {
+		raw, err := crd.Bases.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read custom resource definition %s, %w", file, err)
+		}
+
+		definition := &apiextensionsv1.CustomResourceDefinition{}
+		if err := yaml.Unmarshal(raw, definition); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal custom resource definition %s, %w", file, err)
+		}
+
+		if definition.Spec.Group != gvk.Group || definition.Spec.Names.Kind != gvk.Kind {
+			continue
+		}
+
+		return structuralOf(definition, gvk)
+	}
+
+	return nil, fmt.Errorf("%w for %s", ErrMissingSchema, gvk)
+}
+
+// Installed returns the structural schema of a version of a custom resource definition which is
+// installed on the cluster.
+func Installed(ctx context.Context, c client.Client, gvk schema.GroupVersionKind) (*structuralschema.Structural, error) {
+	mapping, err := c.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, fmt.Errorf("%w for %s", ErrMissingSchema, gvk)
+		}
+
+		return nil, fmt.Errorf("unable to map %s to a resource, %w", gvk, err)
+	}
+
+	definition := &apiextensionsv1.CustomResourceDefinition{}
+	if err := c.Get(ctx, client.ObjectKey{Name: mapping.Resource.Resource + "." + gvk.Group}, definition); err != nil {
+		if apierrs.IsNotFound(err) {
+			return nil, fmt.Errorf("%w for %s", ErrMissingSchema, gvk)
+		}
+
+		return nil, fmt.Errorf("unable to retrieve custom resource definition of %s, %w", gvk, err)
+	}
+
+	return structuralOf(definition, gvk)
+}
+
+// UnknownFields returns the paths of the fields of an object which are not defined by its structural
+// schema, which the API server prunes from the object when it is persisted.
+func UnknownFields(object map[string]interface{}, structural *structuralschema.Structural) []string {
+	return pruning.PruneWithOptions(
+		runtime.DeepCopyJSON(object),
+		structural,
+		true,
+		structuralschema.UnknownFieldPathOptions{TrackUnknownFieldPaths: true},
+	)
+}
+
+// structuralOf returns the structural schema of a version of a custom resource definition.
+func structuralOf(
+	definition *apiextensionsv1.CustomResourceDefinition,
+	gvk schema.GroupVersionKind,
+) (*structuralschema.Structural, error) {
+	for i := range definition.Spec.Versions {
+		version := definition.Spec.Versions[i]
+		if version.Name != gvk.Version || version.Schema == nil || version.Schema.OpenAPIV3Schema == nil {
+			continue
+		}
+
+		internal := &apiextensions.JSONSchemaProps{}
+		if err := apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(
+			version.Schema.OpenAPIV3Schema, internal, nil,
+		); err != nil {
+			return nil, fmt.Errorf("unable to convert schema of %s, %w", gvk, err)
+		}
+
+		structural, err := structuralschema.NewStructural(internal)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read structural schema of %s, %w", gvk, err)
+		}
+
+		return structural, nil
+	}
+
+	return nil, fmt.Errorf("%w for %s", ErrMissingSchema, gvk)
+}
+
+// DefaultSpec decodes the spec of an object of the given kind into the destination, as the API
+// server would default it when the spec is empty.
+func DefaultSpec(gvk schema.GroupVersionKind, destination interface{}) error {
+	structural, err := Schema(gvk)
+	if err != nil {
+		return err
+	}
+
+	object := map[string]interface{}{"spec": map[string]interface{}{}}
+	defaulting.Default(object, structural)
+
+	raw, err := json.Marshal(object)
+	if err != nil {
+		return fmt.Errorf("unable to marshal defaulted %s, %w", gvk, err)
+	}
+
+	if err := json.Unmarshal(raw, destination); err != nil {
+		return fmt.Errorf("unable to unmarshal defaulted %s, %w", gvk, err)
+	}
+
+	return nil
+}
@@ -18,9 +18,49 @@ package dependencies
 
 import (
 	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+
+	"github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1/platformconfig"
+	"github.com/tbd-paas/platform-config-operator/internal/issuers"
+	"github.com/tbd-paas/platform-config-operator/internal/rootca"
+	"github.com/tbd-paas/platform-config-operator/internal/trust"
 )
 
 // PlatformConfigCheckReady performs the logic to determine if a PlatformConfig object is ready.
+// A PlatformConfig object is not ready until each of its ClusterIssuers, including that of the
+// private root CA of a local cloud, is ready, and until trust-manager is ready when Bundles are
+// requested, so that the ClusterIssuers and Bundles which await their capability are created once
+// it is ready.  The readiness of each ClusterIssuer is recorded on its status.
 func PlatformConfigCheckReady(r workload.Reconciler, req *workload.Request) (bool, error) {
-	return true, nil
+	component, err := platformconfig.ConvertWorkload(req.Workload)
+	if err != nil {
+		return false, err
+	}
+
+	if !component.Spec.Certificates.Enabled {
+		component.SetIssuerStatuses(nil)
+
+		return true, nil
+	}
+
+	names := make([]string, 0, len(component.Spec.Certificates.Issuers)+1)
+	if component.Spec.Cloud.Local {
+		names = append(names, rootca.IssuerName)
+	}
+
+	for _, issuer := range component.Spec.Certificates.Issuers {
+		names = append(names, issuer.Name)
+	}
+
+	statuses, ready, err := issuers.Statuses(r, req, names)
+	if err != nil {
+		return false, err
+	}
+
+	component.SetIssuerStatuses(statuses)
+
+	if !ready || len(component.Spec.Certificates.Trust.Bundles) == 0 {
+		return ready, nil
+	}
+
+	return trust.Ready(r, req)
 }
@@ -18,9 +18,46 @@ package dependencies
 
 import (
 	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+
+	"github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1/platformoperators"
+	"github.com/tbd-paas/platform-config-operator/internal/metrics"
 )
 
 // PlatformOperatorsCheckReady performs the logic to determine if a PlatformOperators object is ready.
+// The readiness of the certificates of the metrics endpoints is recorded on its status, but does not
+// determine its readiness, as the operators serve their metrics with self-signed certificates until
+// the certificates capability issues them.
 func PlatformOperatorsCheckReady(r workload.Reconciler, req *workload.Request) (bool, error) {
+	component, err := platformoperators.ConvertWorkload(req.Workload)
+	if err != nil {
+		return false, err
+	}
+
+	if !component.Spec.Metrics.Certificate.Enabled {
+		component.SetMetricsCertificateStatuses(nil)
+
+		return true, nil
+	}
+
+	operators := platformoperators.MetricsOperators(component)
+
+	ready, err := metrics.Ready(r, req)
+	if err != nil {
+		return false, err
+	}
+
+	if !ready {
+		component.SetMetricsCertificateStatuses(metrics.Pending(operators))
+
+		return true, nil
+	}
+
+	statuses, _, err := metrics.Statuses(r, req, component.Spec.Namespace, operators)
+	if err != nil {
+		return false, err
+	}
+
+	component.SetMetricsCertificateStatuses(statuses)
+
 	return true, nil
 }
@@ -0,0 +1,76 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package desired
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Cache holds the child resources which are generated for each in-flight request, so that they are
+// generated once per reconciliation, no matter how many times they are requested by the reconciler
+// and its phases.  Each caller receives its own copy of the children, as they are mutated as they
+// are persisted.
+type Cache struct {
+	mu       sync.Mutex
+	children map[*workload.Request][]client.Object
+}
+
+// Get returns the child resources of a request, generating them on the first call for the request.
+func (c *Cache) Get(req *workload.Request, generate func() ([]client.Object, error)) ([]client.Object, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	children, ok := c.children[req]
+	if !ok {
+		generated, err := generate()
+		if err != nil {
+			return nil, err
+		}
+
+		if c.children == nil {
+			c.children = map[*workload.Request][]client.Object{}
+		}
+
+		children = generated
+		c.children[req] = children
+	}
+
+	copied := make([]client.Object, 0, len(children))
+
+	for _, child := range children {
+		object, ok := child.DeepCopyObject().(client.Object)
+		if !ok {
+			return nil, fmt.Errorf("unable to copy child resource %s %s", child.GetObjectKind().GroupVersionKind().Kind, child.GetName())
+		}
+
+		copied = append(copied, object)
+	}
+
+	return copied, nil
+}
+
+// Forget removes the child resources of a request once its reconciliation is complete.
+func (c *Cache) Forget(req *workload.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.children, req)
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package desired
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCacheGeneratesOncePerRequest(t *testing.T) {
+	t.Parallel()
+
+	cache := &Cache{}
+	req := &workload.Request{}
+
+	var calls int
+
+	generate := func() ([]client.Object, error) {
+		calls++
+
+		return []client.Object{&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "platform"}}}, nil
+	}
+
+	first, err := cache.Get(req, generate)
+	require.NoError(t, err)
+
+	// mutations of the returned children must not leak into subsequent callers
+	first[0].SetLabels(map[string]string{"mutated": "true"})
+
+	second, err := cache.Get(req, generate)
+	require.NoError(t, err)
+	require.Equal(t, 1, calls)
+	require.Empty(t, second[0].GetLabels())
+
+	// a new request generates the children again
+	_, err = cache.Get(&workload.Request{}, generate)
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+
+	// a forgotten request generates the children again
+	cache.Forget(req)
+
+	_, err = cache.Get(req, generate)
+	require.NoError(t, err)
+	require.Equal(t, 3, calls)
+}
+
+func TestCacheDoesNotCacheErrors(t *testing.T) {
+	t.Parallel()
+
+	cache := &Cache{}
+	req := &workload.Request{}
+
+	var calls int
+
+	generate := func() ([]client.Object, error) {
+		calls++
+
+		return nil, errors.New("transient")
+	}
+
+	_, err := cache.Get(req, generate)
+	require.Error(t, err)
+
+	_, err = cache.Get(req, generate)
+	require.Error(t, err)
+	require.Equal(t, 2, calls)
+}
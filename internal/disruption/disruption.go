@@ -0,0 +1,155 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package disruption
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/tbd-paas/platform-config-operator/internal/budgets"
+)
+
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+
+// capabilityGroups are the API groups of the custom resources of the capabilities, whose operators
+// control the Deployments of the capability components.
+var capabilityGroups = []string{
+	"certificates.platform.tbd.io",
+	"identity.platform.tbd.io",
+}
+
+// profiles are the pod disruption budgets of the components of a capability for each deployment
+// size.  Every size keeps at least one replica of each component available, so that draining a
+// node never takes out a webhook which admission depends upon.  Larger sizes, which run more
+// replicas, keep a proportion of them available instead.
+var profiles = map[string]interface{}{
+	budgets.SizeSmall:  1,
+	budgets.SizeMedium: 1,
+	budgets.SizeLarge:  "50%",
+}
+
+// IsCapabilityDeployment determines if an object is a Deployment of a capability component, which is
+// one that is controlled by the custom resource of a capability.
+func IsCapabilityDeployment(object client.Object) bool {
+	owner := metav1.GetControllerOf(object)
+	if owner == nil {
+		return false
+	}
+
+	gv, err := schema.ParseGroupVersion(owner.APIVersion)
+	if err != nil {
+		return false
+	}
+
+	for _, group := range capabilityGroups {
+		if gv.Group == group {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Generate returns a PodDisruptionBudget for each Deployment of a capability component in a
+// namespace which runs more than one replica, selecting the pods of the Deployment with its own
+// selector.  The capability operators do not create disruption budgets themselves, and the
+// Deployments only exist once they have been created by the capability operators, so no budgets are
+// returned when generating resources from the command line.  An unknown deployment size resolves to
+// the small profile.
+func Generate(
+	reconciler workload.Reconciler,
+	req *workload.Request,
+	namespace string,
+	labels map[string]interface{},
+	size string,
+) ([]client.Object, error) {
+	if reconciler == nil || req == nil || namespace == "" {
+		return []client.Object{}, nil
+	}
+
+	minAvailable, ok := profiles[size]
+	if !ok {
+		minAvailable = profiles[budgets.SizeSmall]
+	}
+
+	deployments := &appsv1.DeploymentList{}
+	if err := reconciler.List(req.Context, deployments, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("unable to list deployments in namespace %s, %w", namespace, err)
+	}
+
+	sort.Slice(deployments.Items, func(i, j int) bool {
+		return deployments.Items[i].Name < deployments.Items[j].Name
+	})
+
+	budgetObjects := []client.Object{}
+
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+
+		if !IsCapabilityDeployment(deployment) || deployment.Spec.Selector == nil {
+			continue
+		}
+
+		// a budget for a single replica would block draining the node which runs it
+		if deployment.Spec.Replicas == nil || *deployment.Spec.Replicas < 2 {
+			continue
+		}
+
+		selector, err := runtime.DefaultUnstructuredConverter.ToUnstructured(deployment.Spec.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert selector of deployment %s/%s, %w", namespace, deployment.Name, err)
+		}
+
+		budgetObjects = append(budgetObjects, &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "policy/v1",
+				"kind":       "PodDisruptionBudget",
+				"metadata": map[string]interface{}{
+					"name":      deployment.Name,
+					"namespace": namespace,
+					"labels":    copyLabels(labels),
+				},
+				"spec": map[string]interface{}{
+					"minAvailable": minAvailable,
+					"selector":     selector,
+				},
+			},
+		})
+	}
+
+	return budgetObjects, nil
+}
+
+// copyLabels returns a copy of a set of labels, so that mutating the labels of one object does
+// not mutate those of another.
+func copyLabels(labels map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(labels))
+	for key, value := range labels {
+		copied[key] = value
+	}
+
+	return copied
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package disruption
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type fakeReconciler struct {
+	workload.Reconciler
+
+	client client.Client
+}
+
+func (r *fakeReconciler) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return r.client.List(ctx, list, opts...)
+}
+
+func deployment(namespace, name string, replicas int32, ownerAPIVersion string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: ownerAPIVersion,
+				Kind:       "Owner",
+				Name:       "config",
+				UID:        "uid",
+				Controller: ptr.To(true),
+			}},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(replicas),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": name}},
+		},
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	r := &fakeReconciler{
+		client: fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(
+			deployment("certs", "webhook", 2, "certificates.platform.tbd.io/v1alpha1"),
+			deployment("certs", "cainjector", 3, "certificates.platform.tbd.io/v1alpha1"),
+			deployment("certs", "single", 1, "certificates.platform.tbd.io/v1alpha1"),
+			deployment("certs", "tenant", 2, "apps.example.com/v1"),
+			deployment("other", "elsewhere", 2, "certificates.platform.tbd.io/v1alpha1"),
+		).Build(),
+	}
+
+	req := &workload.Request{Context: context.Background(), Log: logr.Discard()}
+
+	objects, err := Generate(r, req, "certs", map[string]interface{}{"a": "b"}, "large")
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+
+	for i, name := range []string{"cainjector", "webhook"} {
+		budget, ok := objects[i].(*unstructured.Unstructured)
+		require.True(t, ok)
+		require.Equal(t, "PodDisruptionBudget", budget.GetKind())
+		require.Equal(t, name, budget.GetName())
+		require.Equal(t, "certs", budget.GetNamespace())
+		require.Equal(t, map[string]string{"a": "b"}, budget.GetLabels())
+		require.Equal(t, map[string]interface{}{
+			"minAvailable": "50%",
+			"selector":     map[string]interface{}{"matchLabels": map[string]interface{}{"app": name}},
+		}, budget.Object["spec"])
+	}
+
+	// an unknown size resolves to the small profile
+	objects, err = Generate(r, req, "certs", nil, "unknown")
+	require.NoError(t, err)
+	require.Equal(t, 1, objects[0].(*unstructured.Unstructured).Object["spec"].(map[string]interface{})["minAvailable"])
+
+	// the deployments are not known when generating resources from the command line
+	objects, err = Generate(nil, nil, "certs", nil, "small")
+	require.NoError(t, err)
+	require.Empty(t, objects)
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuers
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+	"github.com/tbd-paas/platform-config-operator/internal/readiness"
+)
+
+// +kubebuilder:rbac:groups=cert-manager.io,resources=clusterissuers,verbs=get;list;watch;create;update;patch;delete
+
+const (
+	Group   = "cert-manager.io"
+	Version = "v1"
+	Kind    = "ClusterIssuer"
+)
+
+// certManagerName is the name of the CertManager resource of the certificates capability.
+const certManagerName = "config"
+
+var certManagerGVK = schema.GroupVersionKind{
+	Group:   "certificates.platform.tbd.io",
+	Version: "v1alpha1",
+	Kind:    "CertManager",
+}
+
+// Generate returns the ClusterIssuers of the certificates capability.
+func Generate(spec deployv1beta1.PlatformConfigSpecCertificates, labels map[string]interface{}) []client.Object {
+	objects := make([]client.Object, 0, len(spec.Issuers))
+
+	for _, issuer := range spec.Issuers {
+		copied := make(map[string]interface{}, len(labels))
+		for key, value := range labels {
+			copied[key] = value
+		}
+
+		objects = append(objects, &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": Group + "/" + Version,
+				"kind":       Kind,
+				"metadata": map[string]interface{}{
+					"name":   issuer.Name,
+					"labels": copied,
+				},
+				"spec": issuerSpec(issuer),
+			},
+		})
+	}
+
+	return objects
+}
+
+// issuerSpec returns the specification of a ClusterIssuer.
+func issuerSpec(issuer deployv1beta1.Issuer) map[string]interface{} {
+	switch issuer.Type {
+	case deployv1beta1.IssuerTypeCA:
+		return map[string]interface{}{
+			"ca": map[string]interface{}{
+				"secretName": issuer.CA.SecretName,
+			},
+		}
+	case deployv1beta1.IssuerTypeACMEHTTP01:
+		ingress := map[string]interface{}{}
+		if issuer.ACME.HTTP01.IngressClassName != "" {
+			ingress["ingressClassName"] = issuer.ACME.HTTP01.IngressClassName
+		}
+
+		return acmeSpec(issuer, map[string]interface{}{
+			"http01": map[string]interface{}{
+				"ingress": ingress,
+			},
+		})
+	case deployv1beta1.IssuerTypeACMEDNS01Route53:
+		// credentials are not set so that the ambient credentials of the role which is assumed by
+		// cert-manager are used
+		route53 := map[string]interface{}{
+			"region": issuer.ACME.Route53.Region,
+		}
+		if issuer.ACME.Route53.HostedZoneID != "" {
+			route53["hostedZoneID"] = issuer.ACME.Route53.HostedZoneID
+		}
+
+		return acmeSpec(issuer, map[string]interface{}{
+			"dns01": map[string]interface{}{
+				"route53": route53,
+			},
+		})
+	default:
+		return map[string]interface{}{
+			"selfSigned": map[string]interface{}{},
+		}
+	}
+}
+
+// acmeSpec returns the specification of an ACME ClusterIssuer with a single challenge solver.
+func acmeSpec(issuer deployv1beta1.Issuer, solver map[string]interface{}) map[string]interface{} {
+	privateKeySecretName := issuer.ACME.PrivateKeySecretName
+	if privateKeySecretName == "" {
+		privateKeySecretName = issuer.Name + "-account-key"
+	}
+
+	acme := map[string]interface{}{
+		"server": issuer.ACME.Server,
+		"privateKeySecretRef": map[string]interface{}{
+			"name": privateKeySecretName,
+		},
+		"solvers": []interface{}{solver},
+	}
+
+	if issuer.ACME.Email != "" {
+		acme["email"] = issuer.ACME.Email
+	}
+
+	if issuer.ACME.CABundle != "" {
+		acme["caBundle"] = base64.StdEncoding.EncodeToString([]byte(issuer.ACME.CABundle))
+	}
+
+	if issuer.ACME.SkipTLSVerify {
+		acme["skipTLSVerify"] = true
+	}
+
+	return map[string]interface{}{
+		"acme": acme,
+	}
+}
+
+// Ready determines if the ClusterIssuers may be created, which is once the CertManager resource
+// reports that cert-manager is ready.
+func Ready(reconciler workload.Reconciler, req *workload.Request) (bool, error) {
+	return readiness.Capability(reconciler, req, certManagerGVK, certManagerName, schema.GroupKind{Group: Group, Kind: Kind})
+}
+
+// Statuses returns the readiness of each of the named ClusterIssuers, as reported by their Ready
+// condition, and whether all of them are ready.
+func Statuses(
+	reconciler workload.Reconciler,
+	req *workload.Request,
+	names []string,
+) ([]deployv1beta1.IssuerStatus, bool, error) {
+	statuses := make([]deployv1beta1.IssuerStatus, 0, len(names))
+	allReady := true
+
+	for _, name := range names {
+		issuerStatus, err := status(reconciler, req, name)
+		if err != nil {
+			return nil, false, err
+		}
+
+		allReady = allReady && issuerStatus.Ready
+
+		statuses = append(statuses, issuerStatus)
+	}
+
+	return statuses, allReady, nil
+}
+
+// status returns the readiness of a single ClusterIssuer.
+func status(reconciler workload.Reconciler, req *workload.Request, name string) (deployv1beta1.IssuerStatus, error) {
+	issuer := &unstructured.Unstructured{}
+	issuer.SetGroupVersionKind(schema.GroupVersionKind{Group: Group, Version: Version, Kind: Kind})
+
+	if err := reconciler.Get(req.Context, client.ObjectKey{Name: name}, issuer); err != nil {
+		if apierrs.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return deployv1beta1.IssuerStatus{
+				Name:    name,
+				Message: "waiting for the certificates capability to be ready",
+			}, nil
+		}
+
+		return deployv1beta1.IssuerStatus{}, fmt.Errorf("unable to retrieve %s %s, %w", Kind, name, err)
+	}
+
+	ready, message, reported, err := readiness.ReadyCondition(issuer)
+	if err != nil {
+		return deployv1beta1.IssuerStatus{}, err
+	}
+
+	if !reported {
+		return deployv1beta1.IssuerStatus{Name: name, Message: "waiting for the issuer to report its readiness"}, nil
+	}
+
+	if ready {
+		return deployv1beta1.IssuerStatus{Name: name, Ready: true}, nil
+	}
+
+	return deployv1beta1.IssuerStatus{Name: name, Message: message}, nil
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuers
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+)
+
+var issuerGVK = schema.GroupVersionKind{Group: Group, Version: Version, Kind: Kind}
+
+type fakeReconciler struct {
+	workload.Reconciler
+
+	client client.Client
+	mapper *meta.DefaultRESTMapper
+}
+
+func (r *fakeReconciler) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return r.client.Get(ctx, key, obj, opts...)
+}
+
+func (r *fakeReconciler) RESTMapper() meta.RESTMapper {
+	return r.mapper
+}
+
+func newFakeReconciler(objects ...client.Object) *fakeReconciler {
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{certManagerGVK.GroupVersion(), issuerGVK.GroupVersion()})
+	mapper.Add(certManagerGVK, meta.RESTScopeRoot)
+	mapper.Add(issuerGVK, meta.RESTScopeRoot)
+
+	return &fakeReconciler{
+		client: fake.NewClientBuilder().WithScheme(runtime.NewScheme()).WithRESTMapper(mapper).WithObjects(objects...).Build(),
+		mapper: mapper,
+	}
+}
+
+func certManager(state string) client.Object {
+	object := &unstructured.Unstructured{}
+	object.SetGroupVersionKind(certManagerGVK)
+	object.SetName(certManagerName)
+	object.Object["status"] = map[string]interface{}{
+		"created": true,
+		"conditions": []interface{}{
+			map[string]interface{}{"phase": "Check-Ready", "state": state},
+		},
+	}
+
+	return object
+}
+
+func clusterIssuer(name string, conditions ...interface{}) client.Object {
+	object := &unstructured.Unstructured{}
+	object.SetGroupVersionKind(issuerGVK)
+	object.SetName(name)
+
+	if len(conditions) > 0 {
+		object.Object["status"] = map[string]interface{}{"conditions": conditions}
+	}
+
+	return object
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	spec := deployv1beta1.PlatformConfigSpecCertificates{
+		Issuers: []deployv1beta1.Issuer{
+			{Name: "self-signed", Type: deployv1beta1.IssuerTypeSelfSigned},
+			{Name: "ca", Type: deployv1beta1.IssuerTypeCA, CA: deployv1beta1.IssuerCA{SecretName: "ca-key-pair"}},
+			{
+				Name: "http01",
+				Type: deployv1beta1.IssuerTypeACMEHTTP01,
+				ACME: deployv1beta1.IssuerACME{
+					Server:   "https://pebble/dir",
+					CABundle: "pem",
+					HTTP01:   deployv1beta1.IssuerACMEHTTP01{IngressClassName: "nginx"},
+				},
+			},
+			{
+				Name: "route53",
+				Type: deployv1beta1.IssuerTypeACMEDNS01Route53,
+				ACME: deployv1beta1.IssuerACME{
+					Server:  "https://acme/dir",
+					Email:   "ops@example.com",
+					Route53: deployv1beta1.IssuerACMERoute53{Region: "us-west-2"},
+				},
+			},
+		},
+	}
+
+	objects := Generate(spec, map[string]interface{}{"app.kubernetes.io/part-of": "platform"})
+	require.Len(t, objects, 4)
+
+	specOf := func(i int) map[string]interface{} {
+		object := objects[i].(*unstructured.Unstructured)
+
+		require.Equal(t, issuerGVK, object.GroupVersionKind())
+		require.Equal(t, "platform", object.GetLabels()["app.kubernetes.io/part-of"])
+
+		fields, _, err := unstructured.NestedMap(object.Object, "spec")
+		require.NoError(t, err)
+
+		return fields
+	}
+
+	require.Equal(t, map[string]interface{}{"selfSigned": map[string]interface{}{}}, specOf(0))
+	require.Equal(t, map[string]interface{}{"ca": map[string]interface{}{"secretName": "ca-key-pair"}}, specOf(1))
+
+	http01 := specOf(2)["acme"].(map[string]interface{})
+	require.Equal(t, base64.StdEncoding.EncodeToString([]byte("pem")), http01["caBundle"])
+	require.Equal(t, map[string]interface{}{"name": "http01-account-key"}, http01["privateKeySecretRef"])
+	require.NotContains(t, http01, "email")
+
+	ingress, _, err := unstructured.NestedString(http01["solvers"].([]interface{})[0].(map[string]interface{}), "http01", "ingress", "ingressClassName")
+	require.NoError(t, err)
+	require.Equal(t, "nginx", ingress)
+
+	// the Route53 solver relies upon ambient credentials, so none are set
+	route53 := specOf(3)["acme"].(map[string]interface{})
+	require.Equal(t, "ops@example.com", route53["email"])
+	require.Equal(t, []interface{}{
+		map[string]interface{}{
+			"dns01": map[string]interface{}{
+				"route53": map[string]interface{}{"region": "us-west-2"},
+			},
+		},
+	}, route53["solvers"])
+}
+
+func TestReady(t *testing.T) {
+	t.Parallel()
+
+	req := &workload.Request{Context: context.Background(), Log: logr.Discard()}
+
+	for name, tc := range map[string]struct {
+		objects  []client.Object
+		expected bool
+	}{
+		"not installed": {objects: nil, expected: false},
+		"reconciling":   {objects: []client.Object{certManager("Reconciling")}, expected: false},
+		"complete":      {objects: []client.Object{certManager("Complete")}, expected: true},
+	} {
+		ready, err := Ready(newFakeReconciler(tc.objects...), req)
+		require.NoError(t, err, name)
+		require.Equal(t, tc.expected, ready, name)
+	}
+}
+
+func TestStatuses(t *testing.T) {
+	t.Parallel()
+
+	req := &workload.Request{Context: context.Background(), Log: logr.Discard()}
+
+	r := newFakeReconciler(
+		clusterIssuer("ready", map[string]interface{}{"type": "Ready", "status": "True"}),
+		clusterIssuer("failed", map[string]interface{}{"type": "Ready", "status": "False", "message": "secret not found"}),
+		clusterIssuer("unreported"),
+	)
+
+	statuses, ready, err := Statuses(r, req, []string{"ready"})
+	require.NoError(t, err)
+	require.True(t, ready)
+	require.Equal(t, []deployv1beta1.IssuerStatus{{Name: "ready", Ready: true}}, statuses)
+
+	statuses, ready, err = Statuses(r, req, []string{"ready", "failed", "unreported", "missing"})
+	require.NoError(t, err)
+	require.False(t, ready)
+	require.Equal(t, []deployv1beta1.IssuerStatus{
+		{Name: "ready", Ready: true},
+		{Name: "failed", Message: "secret not found"},
+		{Name: "unreported", Message: "waiting for the issuer to report its readiness"},
+		{Name: "missing", Message: "waiting for the certificates capability to be ready"},
+	}, statuses)
+}
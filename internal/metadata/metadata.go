@@ -0,0 +1,270 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	ErrReservedKey      = errors.New("reserved key may not be set")
+	ErrUnexpectedObject = errors.New("unexpected generated object")
+)
+
+// AppliedLabelsAnnotation and AppliedAnnotationsAnnotation record the keys of the common labels and
+// annotations which were applied to an object, so that those which are no longer common are removed.
+// The keys are reserved, so they may never be set as common annotations themselves.
+const (
+	AppliedLabelsAnnotation      = "metadata.platform.tbd.io/common-labels"
+	AppliedAnnotationsAnnotation = "metadata.platform.tbd.io/common-annotations"
+)
+
+// reservedPrefixes are the prefixes of the label and annotation keys which the platform relies upon.
+var reservedPrefixes = []string{
+	"operator-builder.nukleros.io/",
+	"pod-security.kubernetes.io/",
+}
+
+// reservedKeys are the label and annotation keys which the platform relies upon.
+var reservedKeys = []string{
+	"app.kubernetes.io/managed-by",
+}
+
+// podTemplatePaths are the paths to the pod templates of the kinds of workloads, which also receive
+// the common labels and annotations so that they apply to the pods of the workloads.
+var podTemplatePaths = map[string][]string{
+	"Deployment":  {"spec", "template", "metadata"},
+	"StatefulSet": {"spec", "template", "metadata"},
+	"DaemonSet":   {"spec", "template", "metadata"},
+	"ReplicaSet":  {"spec", "template", "metadata"},
+	"Job":         {"spec", "template", "metadata"},
+	"CronJob":     {"spec", "jobTemplate", "spec", "template", "metadata"},
+}
+
+// IsReserved determines if a label or annotation key is reserved for the platform.  Any key with a
+// prefix in the tbd.io domain is reserved.
+func IsReserved(key string) bool {
+	for _, reserved := range reservedKeys {
+		if key == reserved {
+			return true
+		}
+	}
+
+	for _, prefix := range reservedPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+
+	prefix, _, found := strings.Cut(key, "/")
+
+	return found && (prefix == "tbd.io" || strings.HasSuffix(prefix, ".tbd.io"))
+}
+
+// Validate ensures that none of the common labels or annotations set a reserved key.
+func Validate(labels, annotations map[string]string) error {
+	if err := validate("label", labels); err != nil {
+		return err
+	}
+
+	return validate("annotation", annotations)
+}
+
+// validate ensures that none of the keys of a set of common values are reserved.
+func validate(kind string, values map[string]string) error {
+	for _, key := range sortedKeys(values) {
+		if IsReserved(key) {
+			return fmt.Errorf("%w as common %s, %s", ErrReservedKey, kind, key)
+		}
+	}
+
+	return nil
+}
+
+// Apply applies the common labels and annotations to each object, and to the pod template of each
+// workload.  The common values take precedence over those which are generated for an object, except
+// for the labels of pod templates, which workloads and services select pods by.  The keys which are
+// applied are recorded upon each object, so that keys which are no longer common are removed from
+// it, which requires the object as it exists on the cluster and so does not happen when generating
+// resources from the command line.
+func Apply(
+	reconciler workload.Reconciler,
+	req *workload.Request,
+	objects []client.Object,
+	labels, annotations map[string]string,
+) error {
+	if err := Validate(labels, annotations); err != nil {
+		return err
+	}
+
+	for _, object := range objects {
+		unstructuredObject, ok := object.(*unstructured.Unstructured)
+		if !ok {
+			return fmt.Errorf("%w, %T", ErrUnexpectedObject, object)
+		}
+
+		applied, err := appliedKeys(reconciler, req, unstructuredObject)
+		if err != nil {
+			return err
+		}
+
+		fields := []struct {
+			name       string
+			common     map[string]string
+			applied    []string
+			annotation string
+		}{
+			{name: "labels", common: labels, applied: applied[AppliedLabelsAnnotation], annotation: AppliedLabelsAnnotation},
+			{name: "annotations", common: annotations, applied: applied[AppliedAnnotationsAnnotation], annotation: AppliedAnnotationsAnnotation},
+		}
+
+		for _, field := range fields {
+			if len(field.common) == 0 && len(field.applied) == 0 {
+				continue
+			}
+
+			metadataPath := []string{"metadata", field.name}
+			if err := apply(unstructuredObject, metadataPath, field.common, field.applied, true); err != nil {
+				return err
+			}
+
+			// pods are selected by the labels of their template, so those which are generated are kept
+			if path, ok := podTemplatePaths[unstructuredObject.GetKind()]; ok {
+				templatePath := append(append([]string{}, path...), field.name)
+				if err := apply(unstructuredObject, templatePath, field.common, field.applied, field.name != "labels"); err != nil {
+					return err
+				}
+			}
+
+			// record the keys which were applied, removing the record once there are none
+			var record interface{}
+			if len(field.common) > 0 {
+				record = strings.Join(sortedKeys(field.common), ",")
+			}
+
+			if err := unstructured.SetNestedField(
+				unstructuredObject.Object,
+				record,
+				"metadata", "annotations", field.annotation,
+			); err != nil {
+				return fmt.Errorf("unable to record common %s of %s, %w", field.name, object.GetName(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// appliedKeys returns the keys of the common labels and annotations which were applied to the object
+// as it exists on the cluster, by the annotation which records them.
+func appliedKeys(
+	reconciler workload.Reconciler,
+	req *workload.Request,
+	object *unstructured.Unstructured,
+) (map[string][]string, error) {
+	applied := map[string][]string{}
+
+	if reconciler == nil || req == nil {
+		return applied, nil
+	}
+
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(object.GroupVersionKind())
+
+	if err := reconciler.Get(req.Context, client.ObjectKeyFromObject(object), current); err != nil {
+		if apierrs.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return applied, nil
+		}
+
+		return nil, fmt.Errorf("unable to retrieve %s %s, %w", object.GetKind(), object.GetName(), err)
+	}
+
+	for _, annotation := range []string{AppliedLabelsAnnotation, AppliedAnnotationsAnnotation} {
+		if keys := current.GetAnnotations()[annotation]; keys != "" {
+			applied[annotation] = strings.Split(keys, ",")
+		}
+	}
+
+	return applied, nil
+}
+
+// apply sets the common values upon the map of an object at a path, and removes the keys which were
+// previously applied but are no longer common, by setting them to null so that they are removed
+// when the object is patched.  Values which are generated for the object are only replaced by
+// common values when the common values take precedence, and are never removed.
+func apply(object *unstructured.Unstructured, path []string, common map[string]string, applied []string, precedence bool) error {
+	existing, _, err := unstructured.NestedFieldNoCopy(object.Object, path...)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve %s of %s, %w", strings.Join(path, "."), object.GetName(), err)
+	}
+
+	existingValues, _ := existing.(map[string]interface{})
+
+	values := make(map[string]interface{}, len(existingValues)+len(common)+len(applied))
+	for key, value := range existingValues {
+		values[key] = value
+	}
+
+	for _, key := range applied {
+		if _, ok := common[key]; ok {
+			continue
+		}
+
+		if _, generated := existingValues[key]; !generated {
+			values[key] = nil
+		}
+	}
+
+	for key, value := range common {
+		if _, generated := existingValues[key]; generated && !precedence {
+			continue
+		}
+
+		values[key] = value
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	if err := unstructured.SetNestedField(object.Object, values, path...); err != nil {
+		return fmt.Errorf("unable to set %s of %s, %w", strings.Join(path, "."), object.GetName(), err)
+	}
+
+	return nil
+}
+
+// sortedKeys returns the keys of a map in a stable order.
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type fakeReconciler struct {
+	workload.Reconciler
+
+	client client.Client
+}
+
+func (r *fakeReconciler) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return r.client.Get(ctx, key, obj, opts...)
+}
+
+func configMap(labels map[string]interface{}, annotations map[string]interface{}) *unstructured.Unstructured {
+	metadata := map[string]interface{}{
+		"name":      "config",
+		"namespace": "platform",
+	}
+
+	if labels != nil {
+		metadata["labels"] = labels
+	}
+
+	if annotations != nil {
+		metadata["annotations"] = annotations
+	}
+
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   metadata,
+		},
+	}
+}
+
+func TestValidateReserved(t *testing.T) {
+	t.Parallel()
+
+	for _, key := range []string{
+		"tbd.io/owner",
+		"platform.tbd.io/owner",
+		"operator-builder.nukleros.io/ready",
+		"pod-security.kubernetes.io/enforce",
+		"app.kubernetes.io/managed-by",
+		AppliedLabelsAnnotation,
+	} {
+		require.ErrorIs(t, Validate(map[string]string{key: "value"}, nil), ErrReservedKey, key)
+		require.ErrorIs(t, Validate(nil, map[string]string{key: "value"}), ErrReservedKey, key)
+	}
+
+	for _, key := range []string{"team", "example.com/team", "nottbd.io/team", "app.kubernetes.io/part-of"} {
+		require.NoError(t, Validate(map[string]string{key: "value"}, map[string]string{key: "value"}), key)
+	}
+}
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	deployment := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]interface{}{
+				"name":   "webhook",
+				"labels": map[string]interface{}{"app": "webhook"},
+			},
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"labels": map[string]interface{}{"app": "webhook"},
+					},
+				},
+			},
+		},
+	}
+
+	require.NoError(t, Apply(nil, nil, []client.Object{deployment}, map[string]string{"app": "common", "team": "a"}, nil))
+
+	// common labels take precedence, except upon the pod template
+	require.Equal(t, map[string]string{"app": "common", "team": "a"}, deployment.GetLabels())
+
+	templateLabels, _, err := unstructured.NestedStringMap(deployment.Object, "spec", "template", "metadata", "labels")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"app": "webhook", "team": "a"}, templateLabels)
+
+	require.Equal(t, "app,team", deployment.GetAnnotations()[AppliedLabelsAnnotation])
+	require.NotContains(t, deployment.GetAnnotations(), AppliedAnnotationsAnnotation)
+
+	// reserved keys are rejected
+	require.ErrorIs(t, Apply(nil, nil, []client.Object{deployment}, map[string]string{"tbd.io/team": "a"}, nil), ErrReservedKey)
+}
+
+func TestApplyRemoves(t *testing.T) {
+	t.Parallel()
+
+	current := configMap(
+		map[string]interface{}{"team": "a", "tier": "b", "generated": "c"},
+		map[string]interface{}{
+			AppliedLabelsAnnotation:      "generated,team,tier",
+			AppliedAnnotationsAnnotation: "owner",
+			"owner":                      "someone",
+		},
+	)
+
+	r := &fakeReconciler{client: fake.NewClientBuilder().WithObjects(current).Build()}
+	req := &workload.Request{Context: context.Background(), Log: logr.Discard()}
+
+	desired := configMap(map[string]interface{}{"generated": "c"}, nil)
+
+	require.NoError(t, Apply(r, req, []client.Object{desired}, map[string]string{"team": "x"}, nil))
+
+	// keys which are no longer common are set to null so that patching the object removes them,
+	// except those which are generated for the object
+	labels, _, err := unstructured.NestedMap(desired.Object, "metadata", "labels")
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"team": "x", "tier": nil, "generated": "c"}, labels)
+
+	annotations, _, err := unstructured.NestedMap(desired.Object, "metadata", "annotations")
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{
+		AppliedLabelsAnnotation:      "team",
+		AppliedAnnotationsAnnotation: nil,
+		"owner":                      nil,
+	}, annotations)
+
+	// objects which do not yet exist have nothing to remove
+	missing := configMap(nil, nil)
+	missing.SetName("missing")
+
+	require.NoError(t, Apply(r, req, []client.Object{missing}, nil, nil))
+	require.Empty(t, missing.GetLabels())
+	require.Empty(t, missing.GetAnnotations())
+}
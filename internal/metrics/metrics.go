@@ -0,0 +1,435 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+	"github.com/tbd-paas/platform-config-operator/internal/readiness"
+)
+
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
+
+// the metrics endpoint of this operator authenticates and authorizes requests against the API server
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+const (
+	// PlatformConfigOperator is the name of this operator, whose Deployment and metrics Service are
+	// installed alongside it rather than generated.
+	PlatformConfigOperator = "platform-config-operator"
+
+	// Port is the port at which the metrics endpoints of the operators are served.
+	Port = 8443
+
+	// PortName is the name of the port at which the metrics endpoints of the operators are served.
+	PortName = "https"
+
+	// CertDir is the directory which the metrics certificate is mounted at.
+	CertDir = "/etc/metrics-certs"
+
+	// volumeName is the name of the volume of the metrics certificate.
+	volumeName = "metrics-certs"
+
+	// proxyContainer is the name of the sidecar which serves the metrics endpoints of the capability
+	// operators on behalf of their managers.
+	proxyContainer = "kube-rbac-proxy"
+)
+
+var ErrInvalidPodTemplate = errors.New("invalid pod template")
+
+var certificateGVK = schema.GroupVersionKind{
+	Group:   "cert-manager.io",
+	Version: "v1",
+	Kind:    "Certificate",
+}
+
+// certManagerName is the name of the CertManager resource of the certificates capability.
+const certManagerName = "config"
+
+// CertManagerGVK is the kind of the resource of the certificates capability, upon whose readiness
+// the Certificates of the metrics endpoints depend.
+var CertManagerGVK = schema.GroupVersionKind{
+	Group:   "certificates.platform.tbd.io",
+	Version: "v1alpha1",
+	Kind:    "CertManager",
+}
+
+// CertificateName returns the name of the Certificate of the metrics endpoint of an operator.
+func CertificateName(operator string) string {
+	return operator + "-metrics-cert"
+}
+
+// SecretName returns the name of the Secret which stores the certificate of the metrics endpoint of
+// an operator.
+func SecretName(operator string) string {
+	return operator + "-metrics-server-cert"
+}
+
+// ServiceName returns the name of the Service which fronts the metrics endpoint of an operator.
+func ServiceName(operator string) string {
+	return operator + "-controller-manager-metrics-service"
+}
+
+// Selector returns the labels which select the pods of an operator.
+func Selector(operator string) map[string]interface{} {
+	return map[string]interface{}{
+		"app.kubernetes.io/name": operator,
+		"control-plane":          "controller-manager",
+	}
+}
+
+// Certificate returns the Certificate of the metrics endpoint of an operator, which is valid for the
+// DNS names of its metrics Service.
+func Certificate(operator, namespace, issuerName string, labels map[string]interface{}) client.Object {
+	service := ServiceName(operator)
+
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": certificateGVK.GroupVersion().String(),
+			"kind":       certificateGVK.Kind,
+			"metadata": map[string]interface{}{
+				"name":      CertificateName(operator),
+				"namespace": namespace,
+				"labels":    copyLabels(labels),
+			},
+			"spec": map[string]interface{}{
+				"secretName": SecretName(operator),
+				"dnsNames": []interface{}{
+					service + "." + namespace + ".svc",
+					service + "." + namespace + ".svc.cluster.local",
+				},
+				"issuerRef": map[string]interface{}{
+					"group": certificateGVK.Group,
+					"kind":  "ClusterIssuer",
+					"name":  issuerName,
+				},
+				"privateKey": map[string]interface{}{
+					"rotationPolicy": "Always",
+				},
+			},
+		},
+	}
+}
+
+// Service returns the Service which fronts the metrics endpoint of an operator.
+func Service(operator, namespace string, labels map[string]interface{}) client.Object {
+	serviceLabels := copyLabels(labels)
+	for key, value := range Selector(operator) {
+		serviceLabels[key] = value
+	}
+
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Service",
+			"metadata": map[string]interface{}{
+				"name":      ServiceName(operator),
+				"namespace": namespace,
+				"labels":    serviceLabels,
+			},
+			"spec": map[string]interface{}{
+				"ports": []interface{}{
+					map[string]interface{}{
+						"name":       PortName,
+						"port":       Port,
+						"protocol":   "TCP",
+						"targetPort": PortName,
+					},
+				},
+				"selector": Selector(operator),
+			},
+		},
+	}
+}
+
+// AuthRoles returns the ClusterRole which allows the metrics endpoint of an operator to authenticate
+// and authorize requests against the API server, along with its binding to the service account of
+// the operator.  It is required by both the manager and the kube-rbac-proxy sidecar.
+func AuthRoles(operator, namespace string, labels map[string]interface{}) []client.Object {
+	name := operator + "-metrics-auth-role"
+
+	return []client.Object{
+		&unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "rbac.authorization.k8s.io/v1",
+				"kind":       "ClusterRole",
+				"metadata": map[string]interface{}{
+					"name":   name,
+					"labels": copyLabels(labels),
+				},
+				"rules": []interface{}{
+					map[string]interface{}{
+						"apiGroups": []interface{}{"authentication.k8s.io"},
+						"resources": []interface{}{"tokenreviews"},
+						"verbs":     []interface{}{"create"},
+					},
+					map[string]interface{}{
+						"apiGroups": []interface{}{"authorization.k8s.io"},
+						"resources": []interface{}{"subjectaccessreviews"},
+						"verbs":     []interface{}{"create"},
+					},
+				},
+			},
+		},
+		&unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "rbac.authorization.k8s.io/v1",
+				"kind":       "ClusterRoleBinding",
+				"metadata": map[string]interface{}{
+					"name":   operator + "-metrics-auth-rolebinding",
+					"labels": copyLabels(labels),
+				},
+				"roleRef": map[string]interface{}{
+					"apiGroup": "rbac.authorization.k8s.io",
+					"kind":     "ClusterRole",
+					"name":     name,
+				},
+				"subjects": []interface{}{
+					map[string]interface{}{
+						"kind":      "ServiceAccount",
+						"name":      operator + "-controller-manager",
+						"namespace": namespace,
+					},
+				},
+			},
+		},
+	}
+}
+
+// ServiceMonitor returns the ServiceMonitor which scrapes the metrics endpoint of an operator.  The
+// endpoint is verified against the issuer of its certificate, and Prometheus authenticates with the
+// token of its service account, which must be authorized to get the /metrics non-resource URL.
+func ServiceMonitor(operator, namespace string, labels map[string]interface{}) client.Object {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "monitoring.coreos.com/v1",
+			"kind":       "ServiceMonitor",
+			"metadata": map[string]interface{}{
+				"name":      operator + "-metrics-monitor",
+				"namespace": namespace,
+				"labels":    copyLabels(labels),
+			},
+			"spec": map[string]interface{}{
+				"endpoints": []interface{}{
+					map[string]interface{}{
+						"path":            "/metrics",
+						"port":            PortName,
+						"scheme":          "https",
+						"bearerTokenFile": "/var/run/secrets/kubernetes.io/serviceaccount/token",
+						"tlsConfig": map[string]interface{}{
+							"serverName": ServiceName(operator) + "." + namespace + ".svc",
+							"ca": map[string]interface{}{
+								"secret": map[string]interface{}{
+									"name": SecretName(operator),
+									"key":  "ca.crt",
+								},
+							},
+						},
+					},
+				},
+				"selector": map[string]interface{}{
+					"matchLabels": Selector(operator),
+				},
+			},
+		},
+	}
+}
+
+// Mount mounts the certificate of the metrics endpoint of each operator into the container which
+// serves it, for the Deployments of the operators whose Certificate is among the objects.  The
+// container reloads the certificate as it is renewed.
+func Mount(objects []client.Object) error {
+	certificates := map[client.ObjectKey]bool{}
+
+	for _, object := range objects {
+		if object.GetObjectKind().GroupVersionKind() == certificateGVK {
+			certificates[client.ObjectKeyFromObject(object)] = true
+		}
+	}
+
+	for _, object := range objects {
+		deployment, ok := object.(*unstructured.Unstructured)
+		if !ok || deployment.GetKind() != "Deployment" {
+			continue
+		}
+
+		operator := strings.TrimSuffix(deployment.GetName(), "-controller-manager")
+		if operator == deployment.GetName() {
+			continue
+		}
+
+		if !certificates[client.ObjectKey{Namespace: deployment.GetNamespace(), Name: CertificateName(operator)}] {
+			continue
+		}
+
+		if err := mount(deployment, operator); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mount mounts the certificate of the metrics endpoint of an operator into its Deployment.  The
+// fields are modified in place, as the generated objects hold values which may not be deep copied.
+func mount(deployment *unstructured.Unstructured, operator string) error {
+	podSpec, _, err := unstructured.NestedFieldNoCopy(deployment.Object, "spec", "template", "spec")
+	if err != nil {
+		return fmt.Errorf("unable to read pod template of %s, %w", deployment.GetName(), err)
+	}
+
+	fields, ok := podSpec.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("%w of %s", ErrInvalidPodTemplate, deployment.GetName())
+	}
+
+	containers, _ := fields["containers"].([]interface{})
+	for i := range containers {
+		container, ok := containers[i].(map[string]interface{})
+		if !ok || container["name"] != proxyContainer {
+			continue
+		}
+
+		args, _ := container["args"].([]interface{})
+		container["args"] = append(append([]interface{}{}, args...),
+			"--tls-cert-file="+CertDir+"/tls.crt",
+			"--tls-private-key-file="+CertDir+"/tls.key",
+		)
+
+		mounts, _ := container["volumeMounts"].([]interface{})
+		container["volumeMounts"] = append(append([]interface{}{}, mounts...), map[string]interface{}{
+			"name":      volumeName,
+			"mountPath": CertDir,
+			"readOnly":  true,
+		})
+	}
+
+	// the volume is optional, as is that of this operator, so that a rollout does not wait upon the
+	// certificate being issued, which the container loads once it is mounted
+	volumes, _ := fields["volumes"].([]interface{})
+	fields["volumes"] = append(append([]interface{}{}, volumes...), map[string]interface{}{
+		"name": volumeName,
+		"secret": map[string]interface{}{
+			"secretName": SecretName(operator),
+			"optional":   true,
+		},
+	})
+
+	return nil
+}
+
+// Ready determines if the Certificates may be created, which is once the CertManager resource
+// reports that cert-manager is ready.
+func Ready(reconciler workload.Reconciler, req *workload.Request) (bool, error) {
+	return readiness.Capability(reconciler, req, CertManagerGVK, certManagerName, certificateGVK.GroupKind())
+}
+
+// Statuses returns the readiness of the Certificates of the metrics endpoints of the operators, as
+// reported by their Ready condition, and whether all of them are ready.
+func Statuses(
+	reconciler workload.Reconciler,
+	req *workload.Request,
+	namespace string,
+	operators []string,
+) ([]deployv1beta1.MetricsCertificateStatus, bool, error) {
+	statuses := make([]deployv1beta1.MetricsCertificateStatus, 0, len(operators))
+	allReady := true
+
+	for _, operator := range operators {
+		certificateStatus, err := status(reconciler, req, client.ObjectKey{Namespace: namespace, Name: CertificateName(operator)})
+		if err != nil {
+			return nil, false, err
+		}
+
+		allReady = allReady && certificateStatus.Ready
+
+		statuses = append(statuses, certificateStatus)
+	}
+
+	return statuses, allReady, nil
+}
+
+// Pending returns the status of each of the Certificates of the metrics endpoints of the operators
+// while the certificates capability is not ready.
+func Pending(operators []string) []deployv1beta1.MetricsCertificateStatus {
+	statuses := make([]deployv1beta1.MetricsCertificateStatus, 0, len(operators))
+
+	for _, operator := range operators {
+		statuses = append(statuses, deployv1beta1.MetricsCertificateStatus{
+			Name:    CertificateName(operator),
+			Message: "waiting for the certificates capability to be ready",
+		})
+	}
+
+	return statuses
+}
+
+// status returns the readiness of a single Certificate.
+func status(
+	reconciler workload.Reconciler,
+	req *workload.Request,
+	key client.ObjectKey,
+) (deployv1beta1.MetricsCertificateStatus, error) {
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(certificateGVK)
+
+	if err := reconciler.Get(req.Context, key, certificate); err != nil {
+		if apierrs.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return deployv1beta1.MetricsCertificateStatus{Name: key.Name, Message: "waiting for the certificate to be created"}, nil
+		}
+
+		return deployv1beta1.MetricsCertificateStatus{}, fmt.Errorf("unable to retrieve %s %s, %w", certificateGVK.Kind, key, err)
+	}
+
+	ready, message, reported, err := readiness.ReadyCondition(certificate)
+	if err != nil {
+		return deployv1beta1.MetricsCertificateStatus{}, err
+	}
+
+	if !reported {
+		return deployv1beta1.MetricsCertificateStatus{Name: key.Name, Message: "waiting for the certificate to be issued"}, nil
+	}
+
+	if ready {
+		return deployv1beta1.MetricsCertificateStatus{Name: key.Name, Ready: true}, nil
+	}
+
+	return deployv1beta1.MetricsCertificateStatus{Name: key.Name, Message: message}, nil
+}
+
+// copyLabels returns a copy of the labels, so that the generated objects do not share them.
+func copyLabels(labels map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(labels))
+	for key, value := range labels {
+		copied[key] = value
+	}
+
+	return copied
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const namespace = "tbd-operators-system"
+
+// deployment returns the Deployment of an operator with the named containers.
+func deployment(name string, containers ...string) *unstructured.Unstructured {
+	podContainers := []interface{}{}
+
+	for _, container := range containers {
+		podContainers = append(podContainers, map[string]interface{}{
+			"name": container,
+			"args": []interface{}{"--leader-elect"},
+		})
+	}
+
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+		},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": podContainers,
+				},
+			},
+		},
+	}}
+}
+
+// container returns the named container of a Deployment.
+func container(t *testing.T, deployment *unstructured.Unstructured, name string) map[string]interface{} {
+	t.Helper()
+
+	containers, _, err := unstructured.NestedFieldNoCopy(deployment.Object, "spec", "template", "spec", "containers")
+	require.NoError(t, err)
+
+	for _, c := range containers.([]interface{}) {
+		if fields := c.(map[string]interface{}); fields["name"] == name {
+			return fields
+		}
+	}
+
+	require.Failf(t, "container not found", "container %s", name)
+
+	return nil
+}
+
+func TestMount(t *testing.T) {
+	t.Parallel()
+
+	proxied := deployment("identity-operator-controller-manager", "manager", proxyContainer)
+	uncertified := deployment("other-operator-controller-manager", "manager", proxyContainer)
+	unrelated := deployment("identity-operator-webhook", "manager", proxyContainer)
+
+	objects := []client.Object{
+		Certificate("identity-operator", namespace, "platform-ca", nil),
+		proxied,
+		uncertified,
+		unrelated,
+	}
+
+	require.NoError(t, Mount(objects))
+
+	// the kube-rbac-proxy sidecar serves the endpoint on behalf of the manager
+	proxy := container(t, proxied, proxyContainer)
+	require.Equal(t, []interface{}{
+		"--leader-elect",
+		"--tls-cert-file=" + CertDir + "/tls.crt",
+		"--tls-private-key-file=" + CertDir + "/tls.key",
+	}, proxy["args"])
+	require.Equal(t, []interface{}{
+		map[string]interface{}{"name": volumeName, "mountPath": CertDir, "readOnly": true},
+	}, proxy["volumeMounts"])
+
+	manager := container(t, proxied, "manager")
+	require.Equal(t, []interface{}{"--leader-elect"}, manager["args"])
+	require.NotContains(t, manager, "volumeMounts")
+
+	// the secret is optional, so that a rollout does not wait upon the certificate being issued
+	volumes, _, err := unstructured.NestedSlice(proxied.Object, "spec", "template", "spec", "volumes")
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{
+		map[string]interface{}{
+			"name": volumeName,
+			"secret": map[string]interface{}{
+				"secretName": SecretName("identity-operator"),
+				"optional":   true,
+			},
+		},
+	}, volumes)
+
+	// deployments of operators without a certificate, and other deployments, are not modified
+	for _, object := range []*unstructured.Unstructured{uncertified, unrelated} {
+		require.Equal(t, []interface{}{"--leader-elect"}, container(t, object, proxyContainer)["args"], object.GetName())
+
+		_, found, err := unstructured.NestedFieldNoCopy(object.Object, "spec", "template", "spec", "volumes")
+		require.NoError(t, err)
+		require.False(t, found, object.GetName())
+	}
+}
+
+func TestAuthRoles(t *testing.T) {
+	t.Parallel()
+
+	labels := map[string]interface{}{"app.kubernetes.io/name": "identity-operator"}
+
+	objects := AuthRoles("identity-operator", namespace, labels)
+	require.Len(t, objects, 2)
+
+	role, ok := objects[0].(*unstructured.Unstructured)
+	require.True(t, ok)
+	require.Equal(t, "ClusterRole", role.GetKind())
+	require.Equal(t, []interface{}{
+		map[string]interface{}{
+			"apiGroups": []interface{}{"authentication.k8s.io"},
+			"resources": []interface{}{"tokenreviews"},
+			"verbs":     []interface{}{"create"},
+		},
+		map[string]interface{}{
+			"apiGroups": []interface{}{"authorization.k8s.io"},
+			"resources": []interface{}{"subjectaccessreviews"},
+			"verbs":     []interface{}{"create"},
+		},
+	}, role.Object["rules"])
+
+	// the role is bound to the service account which the operator runs as
+	binding, ok := objects[1].(*unstructured.Unstructured)
+	require.True(t, ok)
+	require.Equal(t, "ClusterRoleBinding", binding.GetKind())
+	require.Equal(t, role.GetName(), binding.Object["roleRef"].(map[string]interface{})["name"])
+	require.Equal(t, []interface{}{
+		map[string]interface{}{
+			"kind":      "ServiceAccount",
+			"name":      "identity-operator-controller-manager",
+			"namespace": namespace,
+		},
+	}, binding.Object["subjects"])
+
+	// the generated objects do not share the labels
+	labels["extra"] = "true"
+	require.NotContains(t, role.Object["metadata"].(map[string]interface{})["labels"], "extra")
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+	"github.com/tbd-paas/platform-config-operator/internal/crdschema"
+)
+
+const (
+	OverrideTypeStrategic = "strategic"
+	OverrideTypeJSON6902  = "json6902"
+)
+
+var (
+	ErrOverrideTargetNotFound  = errors.New("no child resource matches the override target")
+	ErrOverrideFailed          = errors.New("unable to apply override")
+	ErrOverrideChangedIdentity = errors.New("patch may not change the kind, name or namespace of the child resource")
+	ErrOverrideUnknownFields   = errors.New("patch sets fields which are not defined by the schema of the child resource")
+)
+
+// Statuses returns the initial results of applying a set of overrides, in the same order as the
+// overrides, none of which have yet matched a child resource.
+func Statuses(overrides []deployv1beta1.Override) []deployv1beta1.OverrideStatus {
+	if len(overrides) == 0 {
+		return nil
+	}
+
+	statuses := make([]deployv1beta1.OverrideStatus, len(overrides))
+	for i, override := range overrides {
+		statuses[i] = deployv1beta1.OverrideStatus{
+			Target:  override.Target,
+			Message: ErrOverrideTargetNotFound.Error(),
+		}
+	}
+
+	return statuses
+}
+
+// Apply applies the overrides which target a child resource to it, from the mutate hook of the child
+// resource, recording the result of each upon the statuses, which are in the same order as the
+// overrides.  An override which fails to apply is skipped, so that it does not prevent the child
+// resource from being reconciled, and its failure is never replaced by a later success.
+func Apply(
+	reconciler workload.Reconciler,
+	req *workload.Request,
+	object client.Object,
+	overrides []deployv1beta1.Override,
+	statuses []deployv1beta1.OverrideStatus,
+) client.Object {
+	for i, override := range overrides {
+		if !isTarget(object, override.Target) {
+			continue
+		}
+
+		patched, err := applyOverride(reconciler, req, object, override)
+		if err != nil {
+			if i < len(statuses) {
+				statuses[i].Applied = false
+				statuses[i].Message = err.Error()
+			}
+
+			continue
+		}
+
+		if i < len(statuses) && statuses[i].Message == ErrOverrideTargetNotFound.Error() {
+			statuses[i].Applied = true
+			statuses[i].Message = ""
+		}
+
+		object = patched
+	}
+
+	return object
+}
+
+// OverridesError returns an error describing the first override which failed to apply, if any.
+func OverridesError(statuses []deployv1beta1.OverrideStatus) error {
+	for _, status := range statuses {
+		if !status.Applied {
+			return fmt.Errorf(
+				"%w to %s %s, %s",
+				ErrOverrideFailed,
+				status.Target.Kind,
+				status.Target.Name,
+				status.Message,
+			)
+		}
+	}
+
+	return nil
+}
+
+// isTarget determines if an object is the target of an override.
+func isTarget(object client.Object, target deployv1beta1.OverrideTarget) bool {
+	gvk := object.GetObjectKind().GroupVersionKind()
+
+	if gvk.Group != target.Group || gvk.Kind != target.Kind || object.GetName() != target.Name {
+		return false
+	}
+
+	return target.Namespace == "" || object.GetNamespace() == target.Namespace
+}
+
+// applyOverride applies the patch of an override to an object.
+func applyOverride(
+	reconciler workload.Reconciler,
+	req *workload.Request,
+	object client.Object,
+	override deployv1beta1.Override,
+) (client.Object, error) {
+	patch, err := yaml.YAMLToJSON([]byte(override.Patch))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse patch, %w", err)
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert %s to unstructured, %w", object.GetName(), err)
+	}
+
+	original, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal %s, %w", object.GetName(), err)
+	}
+
+	var patched []byte
+
+	switch override.Type {
+	case OverrideTypeJSON6902:
+		decoded, err := jsonpatch.DecodePatch(patch)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode json6902 patch, %w", err)
+		}
+
+		if patched, err = decoded.Apply(original); err != nil {
+			return nil, fmt.Errorf("unable to apply json6902 patch, %w", err)
+		}
+	default:
+		// strategic merge patches require the schema of a built-in kind, so that custom resources
+		// are patched with a JSON merge patch instead, as kubectl does
+		typed, err := scheme.Scheme.New(object.GetObjectKind().GroupVersionKind())
+		if err != nil {
+			if patched, err = jsonpatch.MergePatch(original, patch); err != nil {
+				return nil, fmt.Errorf("unable to apply merge patch, %w", err)
+			}
+
+			break
+		}
+
+		if patched, err = strategicpatch.StrategicMergePatch(original, patch, typed); err != nil {
+			return nil, fmt.Errorf("unable to apply strategic merge patch, %w", err)
+		}
+	}
+
+	result := &unstructured.Unstructured{}
+	if err := result.UnmarshalJSON(patched); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal patched %s, %w", object.GetName(), err)
+	}
+
+	if result.GroupVersionKind() != object.GetObjectKind().GroupVersionKind() ||
+		result.GetName() != object.GetName() ||
+		result.GetNamespace() != object.GetNamespace() {
+		return nil, ErrOverrideChangedIdentity
+	}
+
+	if err := validate(reconciler, req, content, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// validate ensures that a patch only sets fields which are defined by the schema of the child
+// resource, as the API server would otherwise drop the others without the patch failing.  Built-in
+// kinds are validated against their types, unless the unpatched child resource is not valid
+// either, and custom resources against the schema of their installed custom resource definition,
+// which is not known when generating resources from the command line.
+func validate(
+	reconciler workload.Reconciler,
+	req *workload.Request,
+	original map[string]interface{},
+	patched *unstructured.Unstructured,
+) error {
+	gvk := patched.GroupVersionKind()
+
+	if typed, err := scheme.Scheme.New(gvk); err == nil {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructuredWithValidation(original, typed.DeepCopyObject(), true); err != nil {
+			return nil
+		}
+
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructuredWithValidation(patched.Object, typed, true); err != nil {
+			return fmt.Errorf("%w, %s", ErrOverrideUnknownFields, err.Error())
+		}
+
+		return nil
+	}
+
+	if reconciler == nil || req == nil {
+		return nil
+	}
+
+	structural, err := crdschema.Installed(req.Context, reconciler, gvk)
+	if err != nil {
+		if errors.Is(err, crdschema.ErrMissingSchema) {
+			return nil
+		}
+
+		return err
+	}
+
+	known := map[string]bool{}
+	for _, field := range crdschema.UnknownFields(original, structural) {
+		known[field] = true
+	}
+
+	for _, field := range crdschema.UnknownFields(patched.Object, structural) {
+		if !known[field] {
+			return fmt.Errorf("%w, %s", ErrOverrideUnknownFields, field)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+)
+
+type fakeReconciler struct {
+	workload.Reconciler
+
+	client client.Client
+}
+
+func (r *fakeReconciler) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return r.client.Get(ctx, key, obj, opts...)
+}
+
+func (r *fakeReconciler) RESTMapper() meta.RESTMapper {
+	return r.client.RESTMapper()
+}
+
+func deployment() *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]interface{}{
+				"name":      "webhook",
+				"namespace": "platform",
+			},
+			"spec": map[string]interface{}{
+				"replicas": int64(1),
+			},
+		},
+	}
+}
+
+func certManager() *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "certificates.platform.tbd.io/v1alpha1",
+			"kind":       "CertManager",
+			"metadata": map[string]interface{}{
+				"name": "config",
+			},
+			"spec": map[string]interface{}{
+				"namespace": "platform",
+			},
+		},
+	}
+}
+
+func override(kind, group, patch string) deployv1beta1.Override {
+	names := map[string]string{"Deployment": "webhook", "CertManager": "config"}
+
+	return deployv1beta1.Override{
+		Target: deployv1beta1.OverrideTarget{Group: group, Kind: kind, Name: names[kind]},
+		Type:   OverrideTypeStrategic,
+		Patch:  patch,
+	}
+}
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	overrides := []deployv1beta1.Override{
+		override("Deployment", "apps", "spec:\n  replicas: 3"),
+		override("Deployment", "apps", "spec:\n  replica: 3"),
+		override("Service", "", "spec: {}"),
+	}
+
+	statuses := Statuses(overrides)
+
+	patched := Apply(nil, nil, deployment(), overrides, statuses)
+
+	replicas, _, err := unstructured.NestedInt64(patched.(*unstructured.Unstructured).Object, "spec", "replicas")
+	require.NoError(t, err)
+	require.Equal(t, int64(3), replicas)
+
+	require.True(t, statuses[0].Applied)
+	require.Empty(t, statuses[0].Message)
+
+	// a field which the schema of a built-in kind does not define is refused
+	require.False(t, statuses[1].Applied)
+	require.Contains(t, statuses[1].Message, ErrOverrideUnknownFields.Error())
+
+	// an override which targets no child resource is reported as such
+	require.False(t, statuses[2].Applied)
+	require.Equal(t, ErrOverrideTargetNotFound.Error(), statuses[2].Message)
+	require.ErrorIs(t, OverridesError(statuses), ErrOverrideFailed)
+
+	// a failure is not replaced when the child resource is rendered again
+	Apply(nil, nil, deployment(), overrides, statuses)
+	require.False(t, statuses[1].Applied)
+
+	require.Nil(t, Statuses(nil))
+}
+
+func TestApplyCustomResource(t *testing.T) {
+	t.Parallel()
+
+	gvk := schema.GroupVersionKind{Group: "certificates.platform.tbd.io", Version: "v1alpha1", Kind: "CertManager"}
+
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{gvk.GroupVersion()})
+	mapper.Add(gvk, meta.RESTScopeRoot)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiextensionsv1.AddToScheme(scheme))
+
+	definition := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "certmanagers.certificates.platform.tbd.io"},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: gvk.Group,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{Kind: gvk.Kind, Plural: "certmanagers"},
+			Scope: apiextensionsv1.ClusterScoped,
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{
+				Name:    gvk.Version,
+				Served:  true,
+				Storage: true,
+				Schema: &apiextensionsv1.CustomResourceValidation{
+					OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{
+							"spec": {
+								Type: "object",
+								Properties: map[string]apiextensionsv1.JSONSchemaProps{
+									"namespace": {Type: "string"},
+									"replicas":  {Type: "integer"},
+								},
+							},
+						},
+					},
+				},
+			}},
+		},
+	}
+
+	r := &fakeReconciler{
+		client: fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).WithObjects(definition).Build(),
+	}
+
+	req := &workload.Request{Context: context.Background(), Log: logr.Discard()}
+
+	overrides := []deployv1beta1.Override{
+		override("CertManager", gvk.Group, "spec:\n  replicas: 2"),
+		override("CertManager", gvk.Group, "spec:\n  pdb: true"),
+	}
+
+	statuses := Statuses(overrides)
+	Apply(r, req, certManager(), overrides, statuses)
+
+	require.True(t, statuses[0].Applied)
+
+	// a field which the custom resource definition does not define would be pruned, so it is refused
+	require.False(t, statuses[1].Applied)
+	require.Contains(t, statuses[1].Message, "spec.pdb")
+
+	// the schema of a custom resource is not known when generating resources from the command line
+	statuses = Statuses(overrides)
+	Apply(nil, nil, certManager(), overrides, statuses)
+
+	require.True(t, statuses[1].Applied)
+}
@@ -0,0 +1,290 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networkpolicies
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+)
+
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
+
+const (
+	ModeNone    = "none"
+	ModeDefault = "default"
+	ModeStrict  = "strict"
+)
+
+// Ports are the ports which the components in a namespace expose and which must remain reachable
+// when the namespace is isolated.
+type Ports struct {
+	// Webhooks are the ports which serve webhooks called by the API server.  The API server does not
+	// run as a pod that can be selected, so these are reachable from any source.
+	Webhooks []int
+
+	// Metrics are the ports which serve metrics, reachable from the configured metrics scrapers.
+	Metrics []int
+}
+
+// Generate returns the network policies which isolate a namespace according to the requested
+// mode.  No policies are returned when the mode is none.
+func Generate(namespace string, labels map[string]interface{}, spec deployv1beta1.NetworkPolicy, ports Ports) []client.Object {
+	if spec.Mode == ModeNone || namespace == "" {
+		return []client.Object{}
+	}
+
+	strict := spec.Mode == ModeStrict
+
+	policyTypes := []interface{}{"Ingress"}
+	if strict {
+		policyTypes = append(policyTypes, "Egress")
+	}
+
+	policies := []client.Object{
+		// deny all traffic which is not explicitly allowed by another policy
+		policy(namespace, "platform-default-deny", labels, map[string]interface{}{
+			"podSelector": map[string]interface{}{},
+			"policyTypes": policyTypes,
+		}),
+	}
+
+	if len(ports.Webhooks) > 0 {
+		policies = append(policies, policy(namespace, "platform-allow-webhooks", labels, map[string]interface{}{
+			"podSelector": map[string]interface{}{},
+			"policyTypes": []interface{}{"Ingress"},
+			"ingress": []interface{}{
+				map[string]interface{}{
+					"ports": tcpPorts(ports.Webhooks),
+				},
+			},
+		}))
+	}
+
+	if len(ports.Metrics) > 0 {
+		policies = append(policies, policy(namespace, "platform-allow-metrics", labels, map[string]interface{}{
+			"podSelector": map[string]interface{}{},
+			"policyTypes": []interface{}{"Ingress"},
+			"ingress": []interface{}{
+				map[string]interface{}{
+					"from": []interface{}{
+						map[string]interface{}{
+							"namespaceSelector": selector(spec.MetricsScrapers.NamespaceLabels),
+							"podSelector":       selector(spec.MetricsScrapers.PodLabels),
+						},
+					},
+					"ports": tcpPorts(ports.Metrics),
+				},
+			},
+		}))
+	}
+
+	if strict {
+		policies = append(policies,
+			policy(namespace, "platform-allow-dns", labels, map[string]interface{}{
+				"podSelector": map[string]interface{}{},
+				"policyTypes": []interface{}{"Egress"},
+				"egress": []interface{}{
+					map[string]interface{}{
+						"to": []interface{}{
+							map[string]interface{}{
+								"namespaceSelector": map[string]interface{}{
+									"matchLabels": map[string]interface{}{
+										"kubernetes.io/metadata.name": "kube-system",
+									},
+								},
+								"podSelector": map[string]interface{}{
+									"matchLabels": map[string]interface{}{
+										"k8s-app": "kube-dns",
+									},
+								},
+							},
+						},
+						"ports": []interface{}{
+							map[string]interface{}{"port": 53, "protocol": "UDP"},
+							map[string]interface{}{"port": 53, "protocol": "TCP"},
+						},
+					},
+				},
+			}),
+
+			policy(namespace, "platform-allow-api-server", labels, map[string]interface{}{
+				"podSelector": map[string]interface{}{},
+				"policyTypes": []interface{}{"Egress"},
+				"egress":      []interface{}{apiServerRule(spec.APIServerCIDRs)},
+			}),
+		)
+	}
+
+	additional := map[string]interface{}{
+		"podSelector": map[string]interface{}{},
+	}
+
+	additionalTypes := []interface{}{}
+
+	if len(spec.AdditionalIngress) > 0 {
+		additionalTypes = append(additionalTypes, "Ingress")
+		additional["ingress"] = rules(spec.AdditionalIngress, "from")
+	}
+
+	if strict && len(spec.AdditionalEgress) > 0 {
+		additionalTypes = append(additionalTypes, "Egress")
+		additional["egress"] = rules(spec.AdditionalEgress, "to")
+	}
+
+	if len(additionalTypes) > 0 {
+		additional["policyTypes"] = additionalTypes
+
+		policies = append(policies, policy(namespace, "platform-allow-additional", labels, additional))
+	}
+
+	return policies
+}
+
+// apiServerRule returns the egress rule which allows the API server.  Every component in the
+// platform is a client of the API server, so this flow is always needed.  The address of the API
+// server is not known ahead of time, so its well-known ports are allowed to any destination unless
+// its CIDRs are provided.
+func apiServerRule(cidrs []string) map[string]interface{} {
+	rule := map[string]interface{}{
+		"ports": tcpPorts([]int{443, 6443}),
+	}
+
+	if len(cidrs) > 0 {
+		peers := make([]interface{}, len(cidrs))
+
+		for i, cidr := range cidrs {
+			peers[i] = map[string]interface{}{
+				"ipBlock": map[string]interface{}{
+					"cidr": cidr,
+				},
+			}
+		}
+
+		rule["to"] = peers
+	}
+
+	return rule
+}
+
+// policy returns a network policy with a given name and spec.
+func policy(namespace, name string, labels map[string]interface{}, spec map[string]interface{}) client.Object {
+	// each policy receives its own copy of the labels so that mutating one does not mutate all
+	policyLabels := make(map[string]interface{}, len(labels))
+	for key, value := range labels {
+		policyLabels[key] = value
+	}
+
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "networking.k8s.io/v1",
+			"kind":       "NetworkPolicy",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": namespace,
+				"labels":    policyLabels,
+			},
+			"spec": spec,
+		},
+	}
+}
+
+// tcpPorts returns the network policy ports for a set of TCP port numbers.
+func tcpPorts(numbers []int) []interface{} {
+	ports := make([]interface{}, len(numbers))
+
+	for i, number := range numbers {
+		ports[i] = map[string]interface{}{
+			"port":     number,
+			"protocol": "TCP",
+		}
+	}
+
+	return ports
+}
+
+// rules converts the user provided rules into network policy rules, with the peers stored on
+// the peerKey field (from for ingress, to for egress).
+func rules(in []deployv1beta1.NetworkPolicyRule, peerKey string) []interface{} {
+	out := make([]interface{}, len(in))
+
+	for i, rule := range in {
+		converted := map[string]interface{}{}
+
+		if len(rule.Ports) > 0 {
+			ports := make([]interface{}, len(rule.Ports))
+
+			for j, port := range rule.Ports {
+				protocol := port.Protocol
+				if protocol == "" {
+					protocol = "TCP"
+				}
+
+				ports[j] = map[string]interface{}{
+					"port":     port.Port,
+					"protocol": protocol,
+				}
+			}
+
+			converted["ports"] = ports
+		}
+
+		peers := []interface{}{}
+
+		for _, cidr := range rule.CIDRs {
+			peers = append(peers, map[string]interface{}{
+				"ipBlock": map[string]interface{}{
+					"cidr": cidr,
+				},
+			})
+		}
+
+		if len(rule.NamespaceLabels) > 0 || len(rule.PodLabels) > 0 {
+			peers = append(peers, map[string]interface{}{
+				"namespaceSelector": selector(rule.NamespaceLabels),
+				"podSelector":       selector(rule.PodLabels),
+			})
+		}
+
+		// omitting the peers allows all sources or destinations
+		if len(peers) > 0 {
+			converted[peerKey] = peers
+		}
+
+		out[i] = converted
+	}
+
+	return out
+}
+
+// selector returns a label selector which matches a set of labels.  An empty selector matches
+// everything.
+func selector(labels map[string]string) map[string]interface{} {
+	if len(labels) == 0 {
+		return map[string]interface{}{}
+	}
+
+	matchLabels := map[string]interface{}{}
+	for key, value := range labels {
+		matchLabels[key] = value
+	}
+
+	return map[string]interface{}{
+		"matchLabels": matchLabels,
+	}
+}
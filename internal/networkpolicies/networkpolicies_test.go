@@ -0,0 +1,171 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networkpolicies
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+)
+
+var testPorts = Ports{Webhooks: []int{10250}, Metrics: []int{9402}}
+
+// policies returns the generated policies keyed by name.
+func policies(t *testing.T, objects []client.Object) map[string]map[string]interface{} {
+	t.Helper()
+
+	byName := map[string]map[string]interface{}{}
+
+	for _, object := range objects {
+		policy, ok := object.(*unstructured.Unstructured)
+		require.True(t, ok)
+		require.Equal(t, "NetworkPolicy", policy.GetKind())
+		require.Equal(t, "platform", policy.GetNamespace())
+
+		spec, ok := policy.Object["spec"].(map[string]interface{})
+		require.True(t, ok)
+
+		byName[policy.GetName()] = spec
+	}
+
+	return byName
+}
+
+func TestGenerateNone(t *testing.T) {
+	t.Parallel()
+
+	require.Empty(t, Generate("platform", nil, deployv1beta1.NetworkPolicy{Mode: ModeNone}, testPorts))
+	require.Empty(t, Generate("", nil, deployv1beta1.NetworkPolicy{Mode: ModeDefault}, testPorts))
+}
+
+func TestGenerateDefault(t *testing.T) {
+	t.Parallel()
+
+	generated := policies(t, Generate("platform", map[string]interface{}{"a": "b"}, deployv1beta1.NetworkPolicy{
+		Mode: ModeDefault,
+		MetricsScrapers: deployv1beta1.NetworkPolicyPeer{
+			NamespaceLabels: map[string]string{"kubernetes.io/metadata.name": "monitoring"},
+			PodLabels:       map[string]string{"app": "scraper"},
+		},
+		AdditionalEgress: []deployv1beta1.NetworkPolicyRule{{CIDRs: []string{"10.0.0.0/8"}}},
+	}, testPorts))
+
+	require.Len(t, generated, 3)
+
+	// egress is not restricted, so additional egress rules are ignored
+	require.Equal(t, []interface{}{"Ingress"}, generated["platform-default-deny"]["policyTypes"])
+	require.NotContains(t, generated, "platform-allow-dns")
+	require.NotContains(t, generated, "platform-allow-api-server")
+	require.NotContains(t, generated, "platform-allow-additional")
+
+	require.Equal(t, []interface{}{
+		map[string]interface{}{"ports": []interface{}{map[string]interface{}{"port": 10250, "protocol": "TCP"}}},
+	}, generated["platform-allow-webhooks"]["ingress"])
+
+	require.Equal(t, []interface{}{
+		map[string]interface{}{
+			"from": []interface{}{
+				map[string]interface{}{
+					"namespaceSelector": map[string]interface{}{
+						"matchLabels": map[string]interface{}{"kubernetes.io/metadata.name": "monitoring"},
+					},
+					"podSelector": map[string]interface{}{
+						"matchLabels": map[string]interface{}{"app": "scraper"},
+					},
+				},
+			},
+			"ports": []interface{}{map[string]interface{}{"port": 9402, "protocol": "TCP"}},
+		},
+	}, generated["platform-allow-metrics"]["ingress"])
+}
+
+func TestGenerateStrict(t *testing.T) {
+	t.Parallel()
+
+	spec := deployv1beta1.NetworkPolicy{
+		Mode: ModeStrict,
+		AdditionalIngress: []deployv1beta1.NetworkPolicyRule{
+			{Ports: []deployv1beta1.NetworkPolicyPort{{Port: 8443}}, PodLabels: map[string]string{"app": "client"}},
+		},
+		AdditionalEgress: []deployv1beta1.NetworkPolicyRule{
+			{Ports: []deployv1beta1.NetworkPolicyPort{{Port: 5432, Protocol: "TCP"}}, CIDRs: []string{"10.0.0.0/8"}},
+		},
+	}
+
+	generated := policies(t, Generate("platform", nil, spec, testPorts))
+
+	require.Len(t, generated, 6)
+	require.Equal(t, []interface{}{"Ingress", "Egress"}, generated["platform-default-deny"]["policyTypes"])
+	require.Contains(t, generated, "platform-allow-dns")
+
+	// without the addresses of the API server, its ports are allowed to any destination
+	require.Equal(t, []interface{}{
+		map[string]interface{}{"ports": []interface{}{
+			map[string]interface{}{"port": 443, "protocol": "TCP"},
+			map[string]interface{}{"port": 6443, "protocol": "TCP"},
+		}},
+	}, generated["platform-allow-api-server"]["egress"])
+
+	additional := generated["platform-allow-additional"]
+	require.Equal(t, []interface{}{"Ingress", "Egress"}, additional["policyTypes"])
+	require.Equal(t, []interface{}{
+		map[string]interface{}{
+			"ports": []interface{}{map[string]interface{}{"port": 8443, "protocol": "TCP"}},
+			"from": []interface{}{
+				map[string]interface{}{
+					"namespaceSelector": map[string]interface{}{},
+					"podSelector":       map[string]interface{}{"matchLabels": map[string]interface{}{"app": "client"}},
+				},
+			},
+		},
+	}, additional["ingress"])
+	require.Equal(t, []interface{}{
+		map[string]interface{}{
+			"ports": []interface{}{map[string]interface{}{"port": 5432, "protocol": "TCP"}},
+			"to":    []interface{}{map[string]interface{}{"ipBlock": map[string]interface{}{"cidr": "10.0.0.0/8"}}},
+		},
+	}, additional["egress"])
+
+	// the addresses of the API server restrict the egress to the API server
+	spec.APIServerCIDRs = []string{"172.20.0.1/32"}
+
+	generated = policies(t, Generate("platform", nil, spec, testPorts))
+	require.Equal(t, []interface{}{
+		map[string]interface{}{
+			"ports": []interface{}{
+				map[string]interface{}{"port": 443, "protocol": "TCP"},
+				map[string]interface{}{"port": 6443, "protocol": "TCP"},
+			},
+			"to": []interface{}{map[string]interface{}{"ipBlock": map[string]interface{}{"cidr": "172.20.0.1/32"}}},
+		},
+	}, generated["platform-allow-api-server"]["egress"])
+}
+
+func TestGenerateCopiesLabels(t *testing.T) {
+	t.Parallel()
+
+	labels := map[string]interface{}{"a": "b"}
+	objects := Generate("platform", labels, deployv1beta1.NetworkPolicy{Mode: ModeDefault}, testPorts)
+
+	objects[0].SetLabels(map[string]string{"a": "changed"})
+	require.Equal(t, "b", objects[1].GetLabels()["a"])
+	require.Equal(t, "b", labels["a"])
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oidcdiscovery
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:rbac:urls=/.well-known/openid-configuration;/openid/v1/jwks,verbs=get
+
+const (
+	// ConfigurationKey is the key of the ConfigMap which holds the OIDC discovery document, which is
+	// served from <issuer>/.well-known/openid-configuration.
+	ConfigurationKey = "openid-configuration"
+
+	// KeysKey is the key of the ConfigMap which holds the JSON web key set, which is served from
+	// <issuer>/keys.json.
+	KeysKey = "keys.json"
+
+	configurationPath = "/.well-known/openid-configuration"
+	keysPath          = "/openid/v1/jwks"
+
+	// RefreshInterval is the interval at which the discovery documents are retrieved again from the
+	// API server, so that the keys of a rotated signing key are published.
+	RefreshInterval = 5 * time.Minute
+)
+
+var (
+	ErrUnavailable     = errors.New("service account issuer discovery is not served by the API server")
+	ErrInvalidDocument = errors.New("invalid service account issuer discovery document")
+)
+
+// Documents are the discovery documents of a service account issuer.
+type Documents struct {
+	// Issuer is the URL of the issuer, as named by the discovery document.
+	Issuer string
+
+	// Configuration is the OIDC discovery document.
+	Configuration []byte
+
+	// Keys is the JSON web key set which verifies the tokens of the issuer.
+	Keys []byte
+}
+
+// Cache holds the discovery documents which were last retrieved from the API server, so that they
+// are retrieved at most once per refresh interval rather than on every reconciliation.  Failures to
+// retrieve them are not cached.
+type Cache struct {
+	mu        sync.Mutex
+	documents *Documents
+	expires   time.Time
+}
+
+// Get returns the cached documents, retrieving them with fetch once they expire.
+func (c *Cache) Get(fetch func() (*Documents, error)) (*Documents, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.documents != nil && time.Now().Before(c.expires) {
+		return c.documents, nil
+	}
+
+	documents, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	c.documents, c.expires = documents, time.Now().Add(RefreshInterval)
+
+	return documents, nil
+}
+
+// cachingReconciler is a reconciler which caches the discovery documents between reconciliations.
+type cachingReconciler interface {
+	GetDiscoveryCache() *Cache
+}
+
+// Fetch returns the discovery documents of the service account issuer, as served by the API server,
+// from the cache of the reconciler if it has one.  ErrUnavailable is returned when the API server
+// does not serve them.
+func Fetch(reconciler workload.Reconciler, req *workload.Request) (*Documents, error) {
+	if caching, ok := reconciler.(cachingReconciler); ok && caching.GetDiscoveryCache() != nil {
+		return caching.GetDiscoveryCache().Get(func() (*Documents, error) {
+			return fetch(reconciler, req)
+		})
+	}
+
+	return fetch(reconciler, req)
+}
+
+// fetch retrieves the discovery documents of the service account issuer from the API server.
+func fetch(reconciler workload.Reconciler, req *workload.Request) (*Documents, error) {
+	configuration, err := get(reconciler, req, configurationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var discovered map[string]interface{}
+	if err := json.Unmarshal(configuration, &discovered); err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrInvalidDocument, err.Error())
+	}
+
+	issuer, _ := discovered["issuer"].(string)
+	if issuer == "" {
+		return nil, fmt.Errorf("%w, missing issuer", ErrInvalidDocument)
+	}
+
+	// the jwks_uri of the discovery document is the external address of the API server, which is not
+	// necessarily reachable from the operator, so the keys are retrieved from the API server directly
+	keys, err := get(reconciler, req, keysPath)
+	if err != nil {
+		return nil, err
+	}
+
+	compacted := &bytes.Buffer{}
+	if err := json.Compact(compacted, keys); err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrInvalidDocument, err.Error())
+	}
+
+	return &Documents{
+		Issuer:        issuer,
+		Configuration: configuration,
+		Keys:          compacted.Bytes(),
+	}, nil
+}
+
+// Published returns the discovery documents which were previously published to a ConfigMap, or nil
+// if there is none, so that they continue to be served while the API server does not serve them.
+func Published(reconciler workload.Reconciler, req *workload.Request, key client.ObjectKey) (*Documents, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := reconciler.Get(req.Context, key, configMap); err != nil {
+		if apierrs.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("unable to retrieve published service account issuer discovery documents, %w", err)
+	}
+
+	var published map[string]interface{}
+	if err := json.Unmarshal([]byte(configMap.Data[ConfigurationKey]), &published); err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrInvalidDocument, err.Error())
+	}
+
+	issuer, _ := published["issuer"].(string)
+
+	return &Documents{
+		Issuer:        issuer,
+		Configuration: []byte(configMap.Data[ConfigurationKey]),
+		Keys:          []byte(configMap.Data[KeysKey]),
+	}, nil
+}
+
+// Publish returns the discovery documents which are served from the issuer of the API server.  The
+// keys are served from <issuer>/keys.json rather than from the path of the API server.
+func Publish(documents *Documents) (*Documents, error) {
+	// the issuer is published verbatim, as it must match the issuer claim of the tokens exactly
+	issuer := documents.Issuer
+
+	var discovered map[string]interface{}
+	if err := json.Unmarshal(documents.Configuration, &discovered); err != nil {
+		return nil, fmt.Errorf("%w, %s", ErrInvalidDocument, err.Error())
+	}
+
+	discovered["issuer"] = issuer
+	discovered["jwks_uri"] = strings.TrimSuffix(issuer, "/") + "/" + KeysKey
+
+	configuration, err := json.Marshal(discovered)
+	if err != nil {
+		return nil, fmt.Errorf("unable to render service account issuer discovery document, %w", err)
+	}
+
+	return &Documents{
+		Issuer:        issuer,
+		Configuration: configuration,
+		Keys:          documents.Keys,
+	}, nil
+}
+
+// get returns the body of a non-resource path of the API server.
+func get(reconciler workload.Reconciler, req *workload.Request, path string) ([]byte, error) {
+	server, _, err := rest.DefaultServerUrlFor(reconciler.GetManager().GetConfig())
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine the address of the API server, %w", err)
+	}
+
+	server.Path = strings.TrimSuffix(server.Path, "/") + path
+
+	request, err := http.NewRequestWithContext(req.Context, http.MethodGet, server.String(), http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("unable to request %s, %w", path, err)
+	}
+
+	response, err := reconciler.GetManager().GetHTTPClient().Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("unable to request %s, %w", path, err)
+	}
+
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s, %w", path, err)
+	}
+
+	switch {
+	case response.StatusCode == http.StatusNotFound || response.StatusCode == http.StatusForbidden:
+		return nil, fmt.Errorf("%w, %s returned %s", ErrUnavailable, path, response.Status)
+	case response.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("unable to request %s, %s", path, response.Status)
+	}
+
+	return body, nil
+}
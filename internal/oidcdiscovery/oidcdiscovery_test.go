@@ -0,0 +1,124 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oidcdiscovery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type fakeReconciler struct {
+	workload.Reconciler
+
+	client client.Client
+}
+
+func (r *fakeReconciler) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return r.client.Get(ctx, key, obj, opts...)
+}
+
+func documents(issuer string) *Documents {
+	return &Documents{
+		Issuer:        issuer,
+		Configuration: []byte(`{"issuer":"` + issuer + `","jwks_uri":"https://10.0.0.1:6443/openid/v1/jwks"}`),
+		Keys:          []byte(`{"keys":[]}`),
+	}
+}
+
+func TestCache(t *testing.T) {
+	t.Parallel()
+
+	cache := &Cache{}
+
+	var calls int
+
+	fetch := func() (*Documents, error) {
+		calls++
+
+		return documents("https://issuer.local"), nil
+	}
+
+	// failures to retrieve the documents are not cached
+	_, err := cache.Get(func() (*Documents, error) { return nil, ErrUnavailable })
+	require.ErrorIs(t, err, ErrUnavailable)
+
+	for i := 0; i < 3; i++ {
+		cached, err := cache.Get(fetch)
+		require.NoError(t, err)
+		require.Equal(t, "https://issuer.local", cached.Issuer)
+	}
+
+	require.Equal(t, 1, calls)
+}
+
+func TestPublish(t *testing.T) {
+	t.Parallel()
+
+	published, err := Publish(documents("https://issuer.local/"))
+	require.NoError(t, err)
+	require.Equal(t, "https://issuer.local/", published.Issuer)
+
+	var configuration map[string]interface{}
+	require.NoError(t, json.Unmarshal(published.Configuration, &configuration))
+
+	// the issuer is published verbatim, while the keys are served alongside the document
+	require.Equal(t, "https://issuer.local/", configuration["issuer"])
+	require.Equal(t, "https://issuer.local/keys.json", configuration["jwks_uri"])
+
+	_, err = Publish(&Documents{Configuration: []byte("{")})
+	require.True(t, errors.Is(err, ErrInvalidDocument))
+}
+
+func TestPublished(t *testing.T) {
+	t.Parallel()
+
+	req := &workload.Request{Context: context.Background(), Log: logr.Discard()}
+	key := client.ObjectKey{Namespace: "tbd-identity-system", Name: "service-account-issuer-discovery"}
+
+	// there is nothing to keep serving before the documents are first published
+	r := &fakeReconciler{client: fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).Build()}
+
+	published, err := Published(r, req, key)
+	require.NoError(t, err)
+	require.Nil(t, published)
+
+	r = &fakeReconciler{client: fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+			Data: map[string]string{
+				ConfigurationKey: `{"issuer":"https://issuer.local"}`,
+				KeysKey:          `{"keys":[]}`,
+			},
+		},
+	).Build()}
+
+	published, err = Published(r, req, key)
+	require.NoError(t, err)
+	require.Equal(t, "https://issuer.local", published.Issuer)
+	require.Equal(t, `{"keys":[]}`, string(published.Keys))
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podsecurity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/policy"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch
+
+var (
+	ErrLevelViolated      = errors.New("generated workload violates pod security level")
+	ErrInvalidPodTemplate = errors.New("invalid pod template")
+)
+
+// enforceLabels are the labels of a namespace which enforce a pod security level upon it.
+var enforceLabels = []string{
+	"pod-security.kubernetes.io/enforce",
+	"pod-security.kubernetes.io/enforce-version",
+}
+
+// Validate ensures that the pods of each workload which is generated into a namespace satisfy the
+// pod security level which is enforced upon that namespace.  Applying a level which a generated
+// workload violates would prevent its pods from being admitted, so it is refused instead.
+func Validate(namespace, level string, objects []client.Object) error {
+	if namespace == "" || level == "" {
+		return nil
+	}
+
+	parsed, err := api.ParseLevel(level)
+	if err != nil {
+		return fmt.Errorf("unable to parse pod security level %s, %w", level, err)
+	}
+
+	evaluator, err := policy.NewEvaluator(policy.DefaultChecks())
+	if err != nil {
+		return fmt.Errorf("unable to create pod security evaluator, %w", err)
+	}
+
+	levelVersion := api.LevelVersion{Level: parsed, Version: api.LatestVersion()}
+
+	for _, object := range objects {
+		if object.GetNamespace() != namespace {
+			continue
+		}
+
+		template, err := podTemplate(object)
+		if err != nil {
+			return err
+		}
+
+		if template == nil {
+			continue
+		}
+
+		results := policy.AggregateCheckResults(evaluator.EvaluatePod(levelVersion, &template.ObjectMeta, &template.Spec))
+		if !results.Allowed {
+			return fmt.Errorf(
+				"%w %s: %s %s, %s",
+				ErrLevelViolated,
+				level,
+				object.GetObjectKind().GroupVersionKind().Kind,
+				object.GetName(),
+				strings.Join(results.ForbiddenReasons, "; "),
+			)
+		}
+	}
+
+	return nil
+}
+
+// ValidateDeployments ensures that the pods of the selected Deployments in a namespace satisfy the
+// pod security level which is enforced upon that namespace.  It is used for Deployments which are
+// created by other operators, such as those of the capabilities, which are not generated and so
+// only exist on the cluster.  Nothing is validated when generating resources from the command line.
+func ValidateDeployments(
+	reconciler workload.Reconciler,
+	req *workload.Request,
+	namespace, level string,
+	selected func(client.Object) bool,
+) error {
+	if reconciler == nil || req == nil || namespace == "" || level == "" {
+		return nil
+	}
+
+	deployments := &appsv1.DeploymentList{}
+	if err := reconciler.List(req.Context, deployments, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("unable to list deployments in namespace %s, %w", namespace, err)
+	}
+
+	objects := []client.Object{}
+
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+
+		if !selected(deployment) {
+			continue
+		}
+
+		// objects which are listed do not carry their kind, which the pod template is found by
+		deployment.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("Deployment"))
+
+		objects = append(objects, deployment)
+	}
+
+	return Validate(namespace, level, objects)
+}
+
+// Withhold keeps a pod security level from being enforced upon a namespace, by removing the labels
+// which enforce it from the generated namespace.  The labels are set to null rather than deleted,
+// so that they are also removed from the namespace on the cluster when it is patched.  The levels
+// which are audited and warned against are kept, so that violations continue to be reported.
+func Withhold(objects []client.Object, namespace string) error {
+	for _, object := range objects {
+		unstructuredObject, ok := object.(*unstructured.Unstructured)
+		if !ok || unstructuredObject.GetKind() != "Namespace" || unstructuredObject.GetName() != namespace {
+			continue
+		}
+
+		labels, _, err := unstructured.NestedMap(unstructuredObject.Object, "metadata", "labels")
+		if err != nil {
+			return fmt.Errorf("unable to retrieve labels of namespace %s, %w", namespace, err)
+		}
+
+		if labels == nil {
+			labels = map[string]interface{}{}
+		}
+
+		for _, label := range enforceLabels {
+			labels[label] = nil
+		}
+
+		if err := unstructured.SetNestedMap(unstructuredObject.Object, labels, "metadata", "labels"); err != nil {
+			return fmt.Errorf("unable to withhold pod security level of namespace %s, %w", namespace, err)
+		}
+	}
+
+	return nil
+}
+
+// podTemplate returns the pod template of a workload, or nil if the object does not run pods.
+func podTemplate(object client.Object) (*corev1.PodTemplateSpec, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert %s to unstructured, %w", object.GetName(), err)
+	}
+
+	var path []string
+
+	switch object.GetObjectKind().GroupVersionKind().Kind {
+	case "Deployment", "StatefulSet", "DaemonSet", "ReplicaSet", "Job":
+		path = []string{"spec", "template"}
+	case "CronJob":
+		path = []string{"spec", "jobTemplate", "spec", "template"}
+	case "Pod":
+		path = []string{}
+	default:
+		return nil, nil
+	}
+
+	raw := content
+	if len(path) > 0 {
+		nested, found, err := unstructured.NestedFieldNoCopy(content, path...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find pod template of %s, %w", object.GetName(), err)
+		}
+
+		if !found {
+			return nil, nil
+		}
+
+		nestedMap, ok := nested.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w for %s", ErrInvalidPodTemplate, object.GetName())
+		}
+
+		raw = nestedMap
+	}
+
+	template := &corev1.PodTemplateSpec{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(raw, template); err != nil {
+		return nil, fmt.Errorf("unable to convert pod template of %s, %w", object.GetName(), err)
+	}
+
+	return template, nil
+}
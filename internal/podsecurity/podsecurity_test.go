@@ -0,0 +1,135 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podsecurity
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type fakeReconciler struct {
+	workload.Reconciler
+
+	client client.Client
+}
+
+func (r *fakeReconciler) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return r.client.List(ctx, list, opts...)
+}
+
+func deployment(namespace, name string, privileged bool) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  name,
+						Image: name,
+						SecurityContext: &corev1.SecurityContext{
+							Privileged: ptr.To(privileged),
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func TestValidateDeployments(t *testing.T) {
+	t.Parallel()
+
+	r := &fakeReconciler{
+		client: fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(
+			deployment("certs", "webhook", false),
+			deployment("certs", "tenant", true),
+			deployment("other", "elsewhere", true),
+		).Build(),
+	}
+
+	req := &workload.Request{Context: context.Background(), Log: logr.Discard()}
+
+	all := func(client.Object) bool { return true }
+	unprivileged := func(object client.Object) bool { return object.GetName() != "tenant" }
+
+	// a privileged deployment violates the baseline level
+	err := ValidateDeployments(r, req, "certs", "baseline", all)
+	require.ErrorIs(t, err, ErrLevelViolated)
+	require.ErrorContains(t, err, "Deployment tenant")
+
+	// deployments which are not selected are not validated
+	require.NoError(t, ValidateDeployments(r, req, "certs", "baseline", unprivileged))
+
+	// the privileged level admits every deployment
+	require.NoError(t, ValidateDeployments(r, req, "certs", "privileged", all))
+
+	// the deployments are not known when generating resources from the command line
+	require.NoError(t, ValidateDeployments(nil, nil, "certs", "restricted", all))
+}
+
+func TestWithhold(t *testing.T) {
+	t.Parallel()
+
+	namespace := func(name string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Namespace",
+			"metadata": map[string]interface{}{
+				"name": name,
+				"labels": map[string]interface{}{
+					"pod-security.kubernetes.io/enforce":         "restricted",
+					"pod-security.kubernetes.io/enforce-version": "latest",
+					"pod-security.kubernetes.io/warn":            "restricted",
+				},
+			},
+		}}
+	}
+
+	certs := namespace("certs")
+	other := namespace("other")
+
+	require.NoError(t, Withhold([]client.Object{certs, other, deployment("certs", "webhook", false)}, "certs"))
+
+	// the enforce labels are set to null so that they are removed when the namespace is patched
+	labels, _, err := unstructured.NestedMap(certs.Object, "metadata", "labels")
+	require.NoError(t, err)
+	require.Contains(t, labels, "pod-security.kubernetes.io/enforce")
+	require.Nil(t, labels["pod-security.kubernetes.io/enforce"])
+	require.Contains(t, labels, "pod-security.kubernetes.io/enforce-version")
+	require.Nil(t, labels["pod-security.kubernetes.io/enforce-version"])
+
+	// the level which is warned against is kept
+	require.Equal(t, "restricted", labels["pod-security.kubernetes.io/warn"])
+
+	// other namespaces are untouched
+	require.Equal(t, "restricted", other.GetLabels()["pod-security.kubernetes.io/enforce"])
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+)
+
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=core,resources=services,verbs=get
+
+const (
+	EnvHTTPProxy  = "HTTP_PROXY"
+	EnvHTTPSProxy = "HTTPS_PROXY"
+	EnvNoProxy    = "NO_PROXY"
+
+	// ManagedAnnotation records, upon each Deployment whose containers are injected by the operator,
+	// the names of the environment variables which were injected, so that they are removed once they
+	// are no longer desired.
+	ManagedAnnotation = "deploy.platform.tbd.io/managed-env"
+)
+
+// apiServerService is the service through which in-cluster clients reach the API server.
+var apiServerService = client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "kubernetes"}
+
+// clusterLocal are the destinations which are always reached without the proxy.
+var clusterLocal = []string{
+	"localhost",
+	"127.0.0.1",
+	".svc",
+	".cluster.local",
+}
+
+// Configured determines if a proxy is configured.
+func Configured(spec deployv1beta1.Proxy) bool {
+	return spec.HTTPProxy != "" || spec.HTTPSProxy != ""
+}
+
+// Resolve returns the proxy configuration with the service addresses of the API server added to the
+// destinations which are reached without the proxy, as in-cluster clients reach the API server by
+// its address rather than by name.  Nothing is added when a proxy is not configured or when
+// generating resources from the command line.
+func Resolve(reconciler workload.Reconciler, req *workload.Request, spec deployv1beta1.Proxy) (deployv1beta1.Proxy, error) {
+	if !Configured(spec) || reconciler == nil || req == nil {
+		return spec, nil
+	}
+
+	service := &corev1.Service{}
+	if err := reconciler.GetManager().GetAPIReader().Get(req.Context, apiServerService, service); err != nil {
+		return spec, fmt.Errorf("unable to retrieve service %s of the API server, %w", apiServerService, err)
+	}
+
+	addresses := service.Spec.ClusterIPs
+	if len(addresses) == 0 && service.Spec.ClusterIP != "" {
+		addresses = []string{service.Spec.ClusterIP}
+	}
+
+	resolved := *spec.DeepCopy()
+	resolved.NoProxy = append(append([]string{}, addresses...), spec.NoProxy...)
+
+	return resolved, nil
+}
+
+// Variables returns the environment variables of a platform container, which are the proxy
+// variables, if a proxy is configured, followed by any additional environment variables.
+func Variables(spec deployv1beta1.Proxy, extra []deployv1beta1.EnvVar) []corev1.EnvVar {
+	variables := []corev1.EnvVar{}
+
+	if Configured(spec) {
+		if spec.HTTPProxy != "" {
+			variables = append(variables, corev1.EnvVar{Name: EnvHTTPProxy, Value: spec.HTTPProxy})
+		}
+
+		if spec.HTTPSProxy != "" {
+			variables = append(variables, corev1.EnvVar{Name: EnvHTTPSProxy, Value: spec.HTTPSProxy})
+		}
+
+		variables = append(variables, corev1.EnvVar{Name: EnvNoProxy, Value: NoProxy(spec)})
+	}
+
+	for _, variable := range extra {
+		variables = append(variables, corev1.EnvVar{Name: variable.Name, Value: variable.Value})
+	}
+
+	return variables
+}
+
+// Env returns the environment of a platform container in its unstructured form.
+func Env(spec deployv1beta1.Proxy, extra []deployv1beta1.EnvVar) []interface{} {
+	variables := Variables(spec, extra)
+
+	env := make([]interface{}, 0, len(variables))
+	for _, variable := range variables {
+		env = append(env, map[string]interface{}{
+			"name":  variable.Name,
+			"value": variable.Value,
+		})
+	}
+
+	return env
+}
+
+// NoProxy returns the value of NO_PROXY, which includes the cluster local names, the service CIDR of
+// the cluster, when it is set, and any additional destinations of the proxy configuration.
+// Kubernetes does not expose the service CIDR of the cluster, so it is only included when it is
+// provided with the proxy configuration.
+func NoProxy(spec deployv1beta1.Proxy) string {
+	noProxy := append([]string{}, clusterLocal...)
+
+	for _, destination := range append([]string{spec.ServiceCIDR}, spec.NoProxy...) {
+		if destination != "" {
+			noProxy = append(noProxy, destination)
+		}
+	}
+
+	return strings.Join(noProxy, ",")
+}
+
+// Inject sets the environment variables upon the containers of the selected Deployments in each of
+// the namespaces, and removes those which it previously injected but are no longer desired.  It is
+// used for Deployments which are created by other operators, such as those of the capabilities,
+// whose custom resources do not carry an environment.  Those operators leave variables which they do
+// not set in place, and a Deployment whose containers they replace is injected again once its spec
+// changes.
+func Inject(
+	reconciler workload.Reconciler,
+	req *workload.Request,
+	namespaces []string,
+	selected func(client.Object) bool,
+	variables []corev1.EnvVar,
+) error {
+	for _, namespace := range namespaces {
+		deployments := &appsv1.DeploymentList{}
+		if err := reconciler.List(req.Context, deployments, client.InNamespace(namespace)); err != nil {
+			return fmt.Errorf("unable to list deployments in namespace %s, %w", namespace, err)
+		}
+
+		for i := range deployments.Items {
+			deployment := &deployments.Items[i]
+			if !selected(deployment) || !deployment.DeletionTimestamp.IsZero() {
+				continue
+			}
+
+			if err := inject(reconciler, req, deployment, variables); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// inject sets the environment variables upon the containers of a Deployment, replacing any variable
+// of the same name and removing those which were previously injected but are no longer desired.
+func inject(
+	reconciler workload.Reconciler,
+	req *workload.Request,
+	deployment *appsv1.Deployment,
+	variables []corev1.EnvVar,
+) error {
+	original := deployment.DeepCopy()
+
+	annotations := maps.Clone(deployment.GetAnnotations())
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	replaced := map[string]bool{}
+
+	if managed := annotations[ManagedAnnotation]; managed != "" {
+		for _, name := range strings.Split(managed, ",") {
+			replaced[name] = true
+		}
+	}
+
+	names := make([]string, 0, len(variables))
+	for _, variable := range variables {
+		replaced[variable.Name] = true
+		names = append(names, variable.Name)
+	}
+
+	containers := deployment.Spec.Template.Spec.Containers
+	for i := range containers {
+		env := []corev1.EnvVar{}
+
+		for _, variable := range containers[i].Env {
+			if !replaced[variable.Name] {
+				env = append(env, variable)
+			}
+		}
+
+		containers[i].Env = append(env, variables...)
+	}
+
+	if len(names) == 0 {
+		delete(annotations, ManagedAnnotation)
+	} else {
+		annotations[ManagedAnnotation] = strings.Join(names, ",")
+	}
+
+	deployment.SetAnnotations(annotations)
+
+	if equality.Semantic.DeepEqual(original, deployment) {
+		return nil
+	}
+
+	if err := reconciler.Patch(req.Context, deployment, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("unable to inject environment of deployment %s/%s, %w", deployment.Namespace, deployment.Name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+)
+
+type fakeManager struct {
+	manager.Manager
+
+	client client.Client
+}
+
+func (m *fakeManager) GetAPIReader() client.Reader { return m.client }
+
+type fakeReconciler struct {
+	workload.Reconciler
+
+	client client.Client
+}
+
+func (r *fakeReconciler) GetManager() manager.Manager { return &fakeManager{client: r.client} }
+
+func (r *fakeReconciler) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return r.client.List(ctx, list, opts...)
+}
+
+func (r *fakeReconciler) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return r.client.Patch(ctx, obj, patch, opts...)
+}
+
+func deployment(name string, env ...corev1.EnvVar) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "capability", Name: name},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "manager", Env: env}},
+				},
+			},
+		},
+	}
+}
+
+func current(t *testing.T, r *fakeReconciler, name string) *appsv1.Deployment {
+	t.Helper()
+
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, r.client.Get(context.Background(), client.ObjectKey{Namespace: "capability", Name: name}, deployment))
+
+	return deployment
+}
+
+func TestEnv(t *testing.T) {
+	t.Parallel()
+
+	extra := []deployv1beta1.EnvVar{{Name: "EXTRA", Value: "value"}}
+
+	require.Equal(t, []interface{}{
+		map[string]interface{}{"name": "EXTRA", "value": "value"},
+	}, Env(deployv1beta1.Proxy{NoProxy: []string{"example.com"}}, extra))
+
+	require.Equal(t, []interface{}{
+		map[string]interface{}{"name": EnvHTTPSProxy, "value": "http://proxy:3128"},
+		map[string]interface{}{"name": EnvNoProxy, "value": "localhost,127.0.0.1,.svc,.cluster.local,10.96.0.0/12,example.com"},
+		map[string]interface{}{"name": "EXTRA", "value": "value"},
+	}, Env(deployv1beta1.Proxy{
+		HTTPSProxy:  "http://proxy:3128",
+		ServiceCIDR: "10.96.0.0/12",
+		NoProxy:     []string{"example.com", ""},
+	}, extra))
+}
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+
+	r := &fakeReconciler{
+		client: fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "kubernetes"},
+			Spec:       corev1.ServiceSpec{ClusterIP: "10.96.0.1", ClusterIPs: []string{"10.96.0.1", "fd00::1"}},
+		}).Build(),
+	}
+
+	req := &workload.Request{Context: context.Background(), Log: logr.Discard()}
+
+	spec := deployv1beta1.Proxy{HTTPSProxy: "http://proxy:3128", NoProxy: []string{"example.com"}}
+
+	// the service addresses of the API server are reached without the proxy, without the service CIDR
+	resolved, err := Resolve(r, req, spec)
+	require.NoError(t, err)
+	require.Equal(t, "localhost,127.0.0.1,.svc,.cluster.local,10.96.0.1,fd00::1,example.com", NoProxy(resolved))
+	require.Equal(t, []string{"example.com"}, spec.NoProxy)
+
+	// nothing is looked up when a proxy is not configured or when generating from the command line
+	unconfigured, err := Resolve(&fakeReconciler{}, req, deployv1beta1.Proxy{})
+	require.NoError(t, err)
+	require.Equal(t, deployv1beta1.Proxy{}, unconfigured)
+
+	generated, err := Resolve(nil, nil, spec)
+	require.NoError(t, err)
+	require.Equal(t, spec, generated)
+}
+
+func TestInject(t *testing.T) {
+	t.Parallel()
+
+	r := &fakeReconciler{
+		client: fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(
+			deployment("component", corev1.EnvVar{Name: "OWN", Value: "own"}, corev1.EnvVar{Name: EnvHTTPSProxy, Value: "stale"}),
+			deployment("unselected"),
+		).Build(),
+	}
+
+	req := &workload.Request{Context: context.Background(), Log: logr.Discard()}
+
+	selected := func(object client.Object) bool { return object.GetName() == "component" }
+
+	variables := Variables(deployv1beta1.Proxy{HTTPSProxy: "http://proxy:3128"}, []deployv1beta1.EnvVar{{Name: "EXTRA", Value: "value"}})
+
+	// the variables replace any of the same name and follow those which the component sets itself
+	require.NoError(t, Inject(r, req, []string{"capability"}, selected, variables))
+
+	injected := current(t, r, "component")
+	require.Equal(t, append([]corev1.EnvVar{{Name: "OWN", Value: "own"}}, variables...), injected.Spec.Template.Spec.Containers[0].Env)
+	require.Equal(t, EnvHTTPSProxy+","+EnvNoProxy+",EXTRA", injected.GetAnnotations()[ManagedAnnotation])
+	require.Empty(t, current(t, r, "unselected").Spec.Template.Spec.Containers[0].Env)
+
+	// injecting the same variables again leaves the Deployment untouched
+	require.NoError(t, Inject(r, req, []string{"capability"}, selected, variables))
+	require.Equal(t, injected.ResourceVersion, current(t, r, "component").ResourceVersion)
+
+	// the variables are injected again once the containers have been replaced
+	replaced := current(t, r, "component")
+	replaced.Spec.Template.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "OWN", Value: "own"}}
+	require.NoError(t, r.client.Update(context.Background(), replaced))
+	require.NoError(t, Inject(r, req, []string{"capability"}, selected, variables))
+	require.Equal(t, injected.Spec.Template.Spec.Containers[0].Env, current(t, r, "component").Spec.Template.Spec.Containers[0].Env)
+
+	// the variables which were injected are removed once they are no longer desired
+	require.NoError(t, Inject(r, req, []string{"capability"}, selected, nil))
+
+	removed := current(t, r, "component")
+	require.Equal(t, []corev1.EnvVar{{Name: "OWN", Value: "own"}}, removed.Spec.Template.Spec.Containers[0].Env)
+	require.NotContains(t, removed.GetAnnotations(), ManagedAnnotation)
+}
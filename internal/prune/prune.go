@@ -0,0 +1,155 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prune
+
+import (
+	"fmt"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"github.com/nukleros/operator-builder-tools/pkg/status"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// childResourceRemover is a workload which is able to remove a child resource from its status.
+type childResourceRemover interface {
+	RemoveChildResourceCondition(*status.ChildResource)
+}
+
+// Orphans deletes the child resources which were previously persisted for a workload, as recorded
+// on its status, but are no longer desired (e.g. because the capability which they belong to was
+// disabled).  Only children which are controlled by the workload are deleted.  Custom resource
+// definitions are never deleted, as deleting them would delete every custom resource of their
+// kind; they are only no longer tracked on the status of the workload and are removed along with
+// the workload itself.
+func Orphans(r workload.Reconciler, req *workload.Request, desired []client.Object) error {
+	// children are garbage collected when the workload itself is deleted
+	if !req.Workload.GetDeletionTimestamp().IsZero() {
+		return nil
+	}
+
+	remover, ok := req.Workload.(childResourceRemover)
+	if !ok {
+		return fmt.Errorf("unable to prune child resources for %T", req.Workload)
+	}
+
+	var pruned bool
+
+	for _, resource := range append([]*status.ChildResource{}, req.Workload.GetChildResourceConditions()...) {
+		if isDesired(resource, desired) {
+			continue
+		}
+
+		if isCustomResourceDefinition(resource) {
+			req.Log.Info(
+				"skipping deletion of custom resource definition which is no longer desired",
+				"name", resource.Name,
+			)
+		} else if err := deleteChild(r, req, resource); err != nil {
+			return err
+		}
+
+		remover.RemoveChildResourceCondition(resource)
+
+		pruned = true
+	}
+
+	if !pruned {
+		return nil
+	}
+
+	if err := r.Status().Update(req.Context, req.Workload); err != nil {
+		return fmt.Errorf("unable to update resource conditions for %s, %w", req.Workload.GetWorkloadGVK().Kind, err)
+	}
+
+	return nil
+}
+
+// isDesired determines if a child resource stored on the status of a workload is still desired.
+func isDesired(resource *status.ChildResource, desired []client.Object) bool {
+	for _, child := range desired {
+		gvk := child.GetObjectKind().GroupVersionKind()
+
+		if gvk.Group == resource.Group &&
+			gvk.Version == resource.Version &&
+			gvk.Kind == resource.Kind &&
+			child.GetName() == resource.Name &&
+			child.GetNamespace() == resource.Namespace {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isCustomResourceDefinition determines if a child resource stored on the status of a workload is
+// a custom resource definition.
+func isCustomResourceDefinition(resource *status.ChildResource) bool {
+	return resource.Group == apiextensionsv1.GroupName && resource.Kind == "CustomResourceDefinition"
+}
+
+// deleteChild deletes a child resource from the cluster if it still exists and is controlled by
+// the workload.
+func deleteChild(r workload.Reconciler, req *workload.Request, resource *status.ChildResource) error {
+	child := &unstructured.Unstructured{}
+	child.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   resource.Group,
+		Version: resource.Version,
+		Kind:    resource.Kind,
+	})
+
+	if err := r.Get(req.Context, client.ObjectKey{Name: resource.Name, Namespace: resource.Namespace}, child); err != nil {
+		if apierrs.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+
+		return fmt.Errorf("unable to retrieve child resource %s %s, %w", resource.Kind, resource.Name, err)
+	}
+
+	if !metav1.IsControlledBy(child, req.Workload) {
+		req.Log.V(2).Info(
+			"skipping deletion of child resource which is not controlled by the workload",
+			"kind", resource.Kind,
+			"name", resource.Name,
+			"namespace", resource.Namespace,
+		)
+
+		return nil
+	}
+
+	req.Log.Info(
+		"deleting child resource which is no longer desired",
+		"kind", resource.Kind,
+		"name", resource.Name,
+		"namespace", resource.Namespace,
+	)
+
+	if err := r.Delete(req.Context, child, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil {
+		if apierrs.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("unable to delete child resource %s %s, %w", resource.Kind, resource.Name, err)
+	}
+
+	return nil
+}
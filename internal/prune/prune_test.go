@@ -0,0 +1,97 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prune
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"github.com/nukleros/operator-builder-tools/pkg/status"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+)
+
+type fakeReconciler struct {
+	workload.Reconciler
+
+	client client.Client
+}
+
+func (r *fakeReconciler) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return r.client.Get(ctx, key, obj, opts...)
+}
+
+func (r *fakeReconciler) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	return r.client.Delete(ctx, obj, opts...)
+}
+
+func (r *fakeReconciler) Status() client.SubResourceWriter {
+	return r.client.Status()
+}
+
+func TestOrphansNeverDeletesCustomResourceDefinitions(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, apiextensionsv1.AddToScheme(scheme))
+	require.NoError(t, deployv1beta1.AddToScheme(scheme))
+
+	config := &deployv1beta1.PlatformConfig{ObjectMeta: metav1.ObjectMeta{Name: "config", UID: "uid"}}
+	owner := []metav1.OwnerReference{*metav1.NewControllerRef(config, deployv1beta1.GroupVersion.WithKind("PlatformConfig"))}
+
+	definition := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "certmanagers.certificates.platform.tbd.io", OwnerReferences: owner},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "discovery", Namespace: "identity", OwnerReferences: owner},
+	}
+
+	config.SetChildResourceCondition(&status.ChildResource{
+		Group: apiextensionsv1.GroupName, Version: "v1", Kind: "CustomResourceDefinition", Name: definition.Name,
+	})
+	config.SetChildResourceCondition(&status.ChildResource{
+		Version: "v1", Kind: "ConfigMap", Name: configMap.Name, Namespace: configMap.Namespace,
+	})
+
+	r := &fakeReconciler{
+		client: fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(config, definition, configMap).
+			WithStatusSubresource(config).
+			Build(),
+	}
+
+	req := &workload.Request{Context: context.Background(), Workload: config, Log: logr.Discard()}
+
+	require.NoError(t, Orphans(r, req, nil))
+	require.Empty(t, config.GetChildResourceConditions())
+
+	require.NoError(t, r.client.Get(req.Context, client.ObjectKeyFromObject(definition), &apiextensionsv1.CustomResourceDefinition{}))
+	require.True(t, apierrs.IsNotFound(r.client.Get(req.Context, client.ObjectKeyFromObject(configMap), &corev1.ConfigMap{})))
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package readiness
+
+import (
+	"fmt"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// phaseStateComplete is the state of a phase of the reconciliation of a capability which completed.
+const phaseStateComplete = "Complete"
+
+// Capability determines if the children of a workload which depend upon a capability may be
+// created, which is once the custom resource of the capability reports that it has created its
+// components and that each phase of its reconciliation is complete, and the API of the children is
+// served.  The CLI renders every child resource.
+func Capability(
+	reconciler workload.Reconciler,
+	req *workload.Request,
+	capability schema.GroupVersionKind,
+	name string,
+	child schema.GroupKind,
+) (bool, error) {
+	if reconciler == nil || req == nil {
+		return true, nil
+	}
+
+	object := &unstructured.Unstructured{}
+	object.SetGroupVersionKind(capability)
+
+	if err := reconciler.Get(req.Context, client.ObjectKey{Name: name}, object); err != nil {
+		if apierrs.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("unable to retrieve %s %s, %w", capability.Kind, name, err)
+	}
+
+	created, _, err := unstructured.NestedBool(object.Object, "status", "created")
+	if err != nil {
+		return false, fmt.Errorf("unable to read status of %s %s, %w", capability.Kind, name, err)
+	}
+
+	if !created {
+		return false, nil
+	}
+
+	conditions, _, err := unstructured.NestedSlice(object.Object, "status", "conditions")
+	if err != nil {
+		return false, fmt.Errorf("unable to read status of %s %s, %w", capability.Kind, name, err)
+	}
+
+	// the capability reports the state of each phase, which are all complete once its components
+	// are ready, whereas created remains set while it reconciles them again
+	for _, condition := range conditions {
+		fields, ok := condition.(map[string]interface{})
+		if !ok || fields["state"] != phaseStateComplete {
+			return false, nil
+		}
+	}
+
+	if _, err := reconciler.RESTMapper().RESTMapping(child); err != nil {
+		if meta.IsNoMatchError(err) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("unable to map %s to a resource, %w", child, err)
+	}
+
+	return true, nil
+}
+
+// Existing returns the children which already exist on the cluster.  Children which depend upon a
+// capability which is not ready are rendered only when they exist, so that they are neither created
+// before the capability can serve them nor pruned while the capability is temporarily not ready.
+func Existing(reconciler workload.Reconciler, req *workload.Request, children []client.Object) ([]client.Object, error) {
+	if reconciler == nil || req == nil {
+		return children, nil
+	}
+
+	existing := []client.Object{}
+
+	for _, child := range children {
+		current := &unstructured.Unstructured{}
+		current.SetGroupVersionKind(child.GetObjectKind().GroupVersionKind())
+
+		if err := reconciler.Get(req.Context, client.ObjectKeyFromObject(child), current); err != nil {
+			if apierrs.IsNotFound(err) || meta.IsNoMatchError(err) {
+				continue
+			}
+
+			return nil, fmt.Errorf("unable to retrieve %s %s, %w", current.GetKind(), child.GetName(), err)
+		}
+
+		existing = append(existing, child)
+	}
+
+	return existing, nil
+}
+
+// ReadyCondition returns whether the Ready condition of an object is true, along with its message,
+// and whether the object reports the condition at all.
+func ReadyCondition(object *unstructured.Unstructured) (ready bool, message string, reported bool, err error) {
+	conditions, _, err := unstructured.NestedSlice(object.Object, "status", "conditions")
+	if err != nil {
+		return false, "", false, fmt.Errorf("unable to read status of %s %s, %w", object.GetKind(), object.GetName(), err)
+	}
+
+	for _, condition := range conditions {
+		fields, ok := condition.(map[string]interface{})
+		if !ok || fields["type"] != "Ready" {
+			continue
+		}
+
+		message, _ = fields["message"].(string)
+
+		return fields["status"] == "True", message, true, nil
+	}
+
+	return false, "", false, nil
+}
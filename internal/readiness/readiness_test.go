@@ -0,0 +1,157 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package readiness
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var (
+	capabilityGVK = schema.GroupVersionKind{Group: "certificates.platform.tbd.io", Version: "v1alpha1", Kind: "CertManager"}
+	issuerGVK     = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "ClusterIssuer"}
+)
+
+type fakeReconciler struct {
+	workload.Reconciler
+
+	client client.Client
+	mapper *meta.DefaultRESTMapper
+}
+
+func (r *fakeReconciler) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return r.client.Get(ctx, key, obj, opts...)
+}
+
+func (r *fakeReconciler) RESTMapper() meta.RESTMapper {
+	return r.mapper
+}
+
+func newFakeReconciler(objects ...client.Object) *fakeReconciler {
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{capabilityGVK.GroupVersion(), issuerGVK.GroupVersion()})
+	mapper.Add(capabilityGVK, meta.RESTScopeRoot)
+
+	return &fakeReconciler{
+		client: fake.NewClientBuilder().WithScheme(runtime.NewScheme()).WithRESTMapper(mapper).WithObjects(objects...).Build(),
+		mapper: mapper,
+	}
+}
+
+func capability(created bool, states ...string) client.Object {
+	conditions := make([]interface{}, len(states))
+	for i, state := range states {
+		conditions[i] = map[string]interface{}{"phase": "Create-Resources", "state": state}
+	}
+
+	object := &unstructured.Unstructured{}
+	object.SetGroupVersionKind(capabilityGVK)
+	object.SetName("config")
+	object.Object["status"] = map[string]interface{}{
+		"created":    created,
+		"conditions": conditions,
+	}
+
+	return object
+}
+
+func issuer(name string) client.Object {
+	object := &unstructured.Unstructured{}
+	object.SetGroupVersionKind(issuerGVK)
+	object.SetName(name)
+
+	return object
+}
+
+func TestCapability(t *testing.T) {
+	t.Parallel()
+
+	req := &workload.Request{Context: context.Background(), Log: logr.Discard()}
+
+	ready := func(r *fakeReconciler) bool {
+		t.Helper()
+
+		ready, err := Capability(r, req, capabilityGVK, "config", issuerGVK.GroupKind())
+		require.NoError(t, err)
+
+		return ready
+	}
+
+	// every child resource is rendered by the command line
+	cli, err := Capability(nil, nil, capabilityGVK, "config", issuerGVK.GroupKind())
+	require.NoError(t, err)
+	require.True(t, cli)
+
+	for name, tc := range map[string]struct {
+		capability client.Object
+		expected   bool
+	}{
+		"missing":             {capability: nil, expected: false},
+		"not created":         {capability: capability(false), expected: false},
+		"reconciling":         {capability: capability(true, "Complete", "Reconciling"), expected: false},
+		"child kind unserved": {capability: capability(true, "Complete", "Complete"), expected: false},
+	} {
+		objects := []client.Object{}
+		if tc.capability != nil {
+			objects = append(objects, tc.capability)
+		}
+
+		require.Equal(t, tc.expected, ready(newFakeReconciler(objects...)), name)
+	}
+
+	// the capability is ready once its phases are complete and the kind of the children is served
+	r := newFakeReconciler(capability(true, "Complete", "Complete"))
+	r.mapper.Add(issuerGVK, meta.RESTScopeRoot)
+
+	require.True(t, ready(r))
+}
+
+func TestExisting(t *testing.T) {
+	t.Parallel()
+
+	req := &workload.Request{Context: context.Background(), Log: logr.Discard()}
+	children := []client.Object{issuer("persisted"), issuer("pending")}
+
+	// every child resource is rendered by the command line
+	existing, err := Existing(nil, nil, children)
+	require.NoError(t, err)
+	require.Len(t, existing, 2)
+
+	// none of the children exist while their kind is not served
+	existing, err = Existing(newFakeReconciler(), req, children)
+	require.NoError(t, err)
+	require.Empty(t, existing)
+
+	// only the children which were persisted are kept
+	r := newFakeReconciler()
+	r.mapper.Add(issuerGVK, meta.RESTScopeRoot)
+	require.NoError(t, r.client.Create(context.Background(), issuer("persisted")))
+
+	existing, err = Existing(r, req, children)
+	require.NoError(t, err)
+	require.Len(t, existing, 1)
+	require.Equal(t, "persisted", existing[0].GetName())
+}
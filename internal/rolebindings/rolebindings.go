@@ -0,0 +1,263 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rolebindings
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+)
+
+// +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;patch
+
+const (
+	// RoleARNAnnotation is the annotation of a service account which names the IAM role that its pods
+	// assume, under the default annotation prefix of the AWS pod identity webhook.  The prefix is not
+	// configurable, as the AWSPodIdentityWebhook resource does not carry it.
+	RoleARNAnnotation = "eks.amazonaws.com/role-arn"
+
+	// ManagedAnnotation records, upon each service account which is annotated by the operator, the key
+	// of the role-arn annotation which was set, so that it is removed once no binding selects the
+	// service account.
+	ManagedAnnotation = "identity.platform.tbd.io/managed-role-arn-annotation"
+)
+
+var ErrMissingAccountID = errors.New("roleName requires cloud.aws.accountID")
+
+// RoleARN returns the ARN of the IAM role of a binding.
+func RoleARN(binding deployv1beta1.IdentityRoleBinding, accountID string) (string, error) {
+	if binding.RoleARN != "" {
+		return binding.RoleARN, nil
+	}
+
+	if accountID == "" {
+		return "", ErrMissingAccountID
+	}
+
+	return "arn:aws:iam::" + accountID + ":role/" + binding.RoleName, nil
+}
+
+// Bind annotates the service accounts which are selected by the bindings with the ARN of the role of
+// the first binding which selects each, and removes the annotations which it previously set from the
+// service accounts which are no longer selected, returning the state of each binding.  Annotations
+// which were not set by the operator are left untouched, and are reported as conflicts.  No service
+// accounts are selected when the bindings are not enabled.
+func Bind(
+	reconciler workload.Reconciler,
+	req *workload.Request,
+	bindings []deployv1beta1.IdentityRoleBinding,
+	accountID string,
+	enabled bool,
+) ([]deployv1beta1.IdentityRoleBindingStatus, error) {
+	if !enabled {
+		bindings = nil
+	}
+
+	namespaces := &corev1.NamespaceList{}
+	if err := reconciler.List(req.Context, namespaces); err != nil {
+		return nil, fmt.Errorf("unable to list namespaces, %w", err)
+	}
+
+	namespaceLabels := make(map[string]labels.Set, len(namespaces.Items))
+	for i := range namespaces.Items {
+		namespaceLabels[namespaces.Items[i].Name] = labels.Set(namespaces.Items[i].GetLabels())
+	}
+
+	serviceAccounts := &corev1.ServiceAccountList{}
+	if err := reconciler.List(req.Context, serviceAccounts); err != nil {
+		return nil, fmt.Errorf("unable to list service accounts, %w", err)
+	}
+
+	statuses := make([]deployv1beta1.IdentityRoleBindingStatus, len(bindings))
+	roleARNs := make([]string, len(bindings))
+
+	for i, binding := range bindings {
+		statuses[i] = deployv1beta1.IdentityRoleBindingStatus{Name: binding.Name}
+
+		roleARN, err := RoleARN(binding, accountID)
+		if err != nil {
+			statuses[i].Message = err.Error()
+
+			continue
+		}
+
+		roleARNs[i] = roleARN
+		statuses[i].RoleARN = roleARN
+	}
+
+	for i := range serviceAccounts.Items {
+		serviceAccount := &serviceAccounts.Items[i]
+		if !serviceAccount.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		// the first binding which selects the service account determines its role
+		bound := -1
+
+		for j, binding := range bindings {
+			if roleARNs[j] == "" || !isSelected(serviceAccount, namespaceLabels[serviceAccount.Namespace], binding) {
+				continue
+			}
+
+			if bound < 0 {
+				bound = j
+
+				continue
+			}
+
+			if roleARNs[j] != roleARNs[bound] {
+				statuses[j].Conflicts = append(statuses[j].Conflicts, conflict(
+					serviceAccount,
+					fmt.Sprintf("bound to %s by the %s binding", roleARNs[bound], bindings[bound].Name),
+				))
+			}
+		}
+
+		desired := ""
+		if bound >= 0 {
+			desired = roleARNs[bound]
+		}
+
+		message, err := annotate(reconciler, req, serviceAccount, desired)
+		if err != nil {
+			return nil, err
+		}
+
+		if bound < 0 {
+			continue
+		}
+
+		if message != "" {
+			statuses[bound].Conflicts = append(statuses[bound].Conflicts, conflict(serviceAccount, message))
+
+			continue
+		}
+
+		statuses[bound].ServiceAccounts++
+	}
+
+	return statuses, nil
+}
+
+// Unbind removes the annotations which were set by the operator from every service account, so that
+// no service account is left assuming a role once the workload which bound it is deleted.
+func Unbind(reconciler workload.Reconciler, req *workload.Request) error {
+	_, err := Bind(reconciler, req, nil, "", false)
+
+	return err
+}
+
+// annotate sets the role-arn annotation of a service account to the desired role, or removes the
+// annotation which was previously set by the operator when no role is desired.  The reason for
+// which an annotation which was not set by the operator conflicts with the desired role is returned.
+func annotate(
+	reconciler workload.Reconciler,
+	req *workload.Request,
+	serviceAccount *corev1.ServiceAccount,
+	desired string,
+) (string, error) {
+	annotations := serviceAccount.GetAnnotations()
+	managedKey, managed := annotations[ManagedAnnotation]
+	current, annotated := annotations[RoleARNAnnotation]
+
+	original := serviceAccount.DeepCopy()
+	updated := maps.Clone(annotations)
+
+	if updated == nil {
+		updated = map[string]string{}
+	}
+
+	var message string
+
+	switch {
+	case desired == "" && !managed:
+		return "", nil
+	case desired == "":
+		delete(updated, managedKey)
+		delete(updated, ManagedAnnotation)
+	case annotated && managedKey != RoleARNAnnotation:
+		// the annotation was set by something other than the operator, so it is only adopted if it
+		// already names the desired role
+		if current != desired {
+			message = fmt.Sprintf("annotated with %s=%s, which is not managed by the operator", RoleARNAnnotation, current)
+		}
+
+		if !managed {
+			return message, nil
+		}
+
+		delete(updated, managedKey)
+		delete(updated, ManagedAnnotation)
+	default:
+		if managed {
+			delete(updated, managedKey)
+		}
+
+		updated[RoleARNAnnotation] = desired
+		updated[ManagedAnnotation] = RoleARNAnnotation
+	}
+
+	if maps.Equal(annotations, updated) {
+		return message, nil
+	}
+
+	serviceAccount.SetAnnotations(updated)
+
+	if err := reconciler.Patch(req.Context, serviceAccount, client.MergeFrom(original)); err != nil {
+		return "", fmt.Errorf("unable to annotate service account %s/%s, %w", serviceAccount.Namespace, serviceAccount.Name, err)
+	}
+
+	return message, nil
+}
+
+// isSelected determines if a service account is selected by a binding.
+func isSelected(
+	serviceAccount *corev1.ServiceAccount,
+	namespaceLabels labels.Set,
+	binding deployv1beta1.IdentityRoleBinding,
+) bool {
+	return matches(serviceAccount.Namespace, namespaceLabels, binding.Namespaces, binding.NamespaceSelector) &&
+		matches(serviceAccount.Name, labels.Set(serviceAccount.GetLabels()), binding.ServiceAccounts, binding.ServiceAccountSelector)
+}
+
+// matches determines if an object is selected either by name or by labels.  A nil selector selects
+// nothing, while an empty selector selects everything.
+func matches(name string, objectLabels labels.Set, names []string, selector map[string]string) bool {
+	for _, candidate := range names {
+		if candidate == name {
+			return true
+		}
+	}
+
+	return selector != nil && labels.SelectorFromSet(selector).Matches(objectLabels)
+}
+
+// conflict returns the conflict of a service account.
+func conflict(serviceAccount *corev1.ServiceAccount, message string) deployv1beta1.IdentityRoleBindingConflict {
+	return deployv1beta1.IdentityRoleBindingConflict{
+		Namespace:      serviceAccount.Namespace,
+		ServiceAccount: serviceAccount.Name,
+		Message:        message,
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rolebindings
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+)
+
+const (
+	roleA = "arn:aws:iam::123456789012:role/a"
+	roleB = "arn:aws:iam::123456789012:role/b"
+)
+
+type fakeReconciler struct {
+	workload.Reconciler
+
+	client client.Client
+}
+
+func (r *fakeReconciler) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return r.client.List(ctx, list, opts...)
+}
+
+func (r *fakeReconciler) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return r.client.Patch(ctx, obj, patch, opts...)
+}
+
+func serviceAccount(name string, annotations map[string]string) *corev1.ServiceAccount {
+	return &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: name, Annotations: annotations},
+	}
+}
+
+func annotations(t *testing.T, r *fakeReconciler, name string) map[string]string {
+	t.Helper()
+
+	current := &corev1.ServiceAccount{}
+	require.NoError(t, r.client.Get(context.Background(), client.ObjectKey{Namespace: "apps", Name: name}, current))
+
+	return current.GetAnnotations()
+}
+
+func TestBind(t *testing.T) {
+	t.Parallel()
+
+	r := &fakeReconciler{
+		client: fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "apps", Labels: map[string]string{"team": "a"}}},
+			serviceAccount("web", nil),
+			serviceAccount("manual", map[string]string{RoleARNAnnotation: roleB}),
+			serviceAccount("adopted", map[string]string{RoleARNAnnotation: roleA}),
+		).Build(),
+	}
+
+	req := &workload.Request{Context: context.Background(), Log: logr.Discard()}
+
+	bindings := []deployv1beta1.IdentityRoleBinding{
+		{Name: "a", NamespaceSelector: map[string]string{"team": "a"}, ServiceAccountSelector: map[string]string{}, RoleARN: roleA},
+		{Name: "b", Namespaces: []string{"apps"}, ServiceAccounts: []string{"web"}, RoleName: "b"},
+		{Name: "unresolved", Namespaces: []string{"apps"}, ServiceAccounts: []string{"web"}, RoleName: "c"},
+	}
+
+	statuses, err := Bind(r, req, bindings, "123456789012", true)
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+
+	// the first binding which selects a service account determines its role
+	require.Equal(t, map[string]string{RoleARNAnnotation: roleA, ManagedAnnotation: RoleARNAnnotation}, annotations(t, r, "web"))
+
+	// an annotation which names the desired role is adopted without being managed
+	require.Equal(t, map[string]string{RoleARNAnnotation: roleA}, annotations(t, r, "adopted"))
+	require.Equal(t, 2, statuses[0].ServiceAccounts)
+
+	// an annotation which was not set by the operator is never overwritten
+	require.Equal(t, map[string]string{RoleARNAnnotation: roleB}, annotations(t, r, "manual"))
+	require.Len(t, statuses[0].Conflicts, 1)
+	require.Equal(t, "manual", statuses[0].Conflicts[0].ServiceAccount)
+
+	// a later binding which selects a service account for another role conflicts with the first
+	require.Equal(t, roleB, statuses[1].RoleARN)
+	require.Zero(t, statuses[1].ServiceAccounts)
+	require.Len(t, statuses[1].Conflicts, 1)
+	require.Contains(t, statuses[1].Conflicts[0].Message, "by the a binding")
+
+	// the role of a binding which is named may not be resolved without an account
+	statuses, err = Bind(r, req, bindings, "", true)
+	require.NoError(t, err)
+	require.Equal(t, ErrMissingAccountID.Error(), statuses[2].Message)
+	require.Equal(t, roleA, annotations(t, r, "web")[RoleARNAnnotation])
+
+	// the annotations which the operator set are removed once no binding selects the service account,
+	// while those which it did not set are left untouched
+	require.NoError(t, Unbind(r, req))
+	require.Empty(t, annotations(t, r, "web"))
+	require.Equal(t, map[string]string{RoleARNAnnotation: roleA}, annotations(t, r, "adopted"))
+	require.Equal(t, map[string]string{RoleARNAnnotation: roleB}, annotations(t, r, "manual"))
+}
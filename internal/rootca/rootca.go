@@ -0,0 +1,475 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rootca
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+)
+
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates/status,verbs=update;patch
+
+const (
+	// SecretName is the name of the secret, in the certificates namespace, which stores the root CA.
+	SecretName = "platform-root-ca"
+
+	// IssuerName is the name of the ClusterIssuer which issues certificates from the root CA.
+	IssuerName = "platform-root-ca"
+
+	// BundleKey is the key in the secret which stores the trusted root CAs, which includes the
+	// previous root CA while rotating.
+	BundleKey = "ca.crt"
+)
+
+var (
+	ErrMissingCertificate       = errors.New("root CA secret does not contain a certificate")
+	ErrUnsupportedKeyAlgorithm  = errors.New("unsupported key algorithm for root CA")
+	ErrUnsupportedECDSAKeySize  = errors.New("unsupported ECDSA key size for root CA")
+	ErrInvalidCertificateConfig = errors.New("invalid root CA configuration")
+)
+
+var certificateGVK = schema.GroupVersionKind{
+	Group:   "cert-manager.io",
+	Version: "v1",
+	Kind:    "CertificateList",
+}
+
+// Ensure ensures that the root CA exists in the certificates namespace and rotates it when it is
+// close to expiring.  A rotated root CA is trusted alongside its predecessor, which is only removed
+// from the trust bundle once every certificate which was issued by the ClusterIssuer of the root CA
+// has been reissued.  Reissuance of those certificates is triggered as part of the rotation.
+//
+// The secret is not a child resource of the workload, so that the root CA is retained if the workload
+// is deleted and recreated.
+func Ensure(
+	reconciler workload.Reconciler,
+	req *workload.Request,
+	namespace string,
+	spec deployv1beta1.RootCA,
+) (*deployv1beta1.RootCAStatus, error) {
+	if spec.RenewBefore.Duration >= spec.Validity.Duration {
+		return nil, fmt.Errorf("%w, renewBefore must be less than validity", ErrInvalidCertificateConfig)
+	}
+
+	// secrets are read directly from the API server so that the secrets of the cluster are not cached
+	secret := &corev1.Secret{}
+
+	err := reconciler.GetManager().GetAPIReader().Get(req.Context, client.ObjectKey{Namespace: namespace, Name: SecretName}, secret)
+	if err != nil && !apierrs.IsNotFound(err) {
+		return nil, fmt.Errorf("unable to retrieve root CA secret, %w", err)
+	}
+
+	if apierrs.IsNotFound(err) {
+		return create(reconciler, req, namespace, spec)
+	}
+
+	current, err := parseCertificate(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := trusted(secret.Data[BundleKey], current)
+
+	// replace the root CA when it is close to expiring, continuing to trust its predecessor
+	if time.Now().Add(spec.RenewBefore.Duration).After(current.NotAfter) {
+		req.Log.Info("rotating root CA", "notAfter", current.NotAfter)
+
+		data, err := generate(spec)
+		if err != nil {
+			return nil, err
+		}
+
+		if current, err = parseCertificate(data[corev1.TLSCertKey]); err != nil {
+			return nil, err
+		}
+
+		bundle = trusted(secret.Data[BundleKey], current)
+
+		secret.Data = data
+		secret.Data[BundleKey] = encode(bundle)
+
+		if err := reconciler.Update(req.Context, secret); err != nil {
+			return nil, fmt.Errorf("unable to update root CA secret, %w", err)
+		}
+	}
+
+	if len(bundle) == 1 {
+		return &deployv1beta1.RootCAStatus{
+			Phase:    deployv1beta1.RootCAPhaseActive,
+			NotAfter: metav1.NewTime(current.NotAfter),
+		}, nil
+	}
+
+	pending, err := reissue(reconciler, req, current)
+	if err != nil {
+		return nil, err
+	}
+
+	if pending > 0 {
+		return &deployv1beta1.RootCAStatus{
+			Phase:               deployv1beta1.RootCAPhaseRotating,
+			NotAfter:            metav1.NewTime(current.NotAfter),
+			PendingCertificates: pending,
+		}, nil
+	}
+
+	// every certificate has been reissued, so the previous root CAs are no longer trusted
+	req.Log.Info("completing rotation of root CA", "notAfter", current.NotAfter)
+
+	secret.Data[BundleKey] = encode([]*x509.Certificate{current})
+
+	if err := reconciler.Update(req.Context, secret); err != nil {
+		return nil, fmt.Errorf("unable to update root CA secret, %w", err)
+	}
+
+	return &deployv1beta1.RootCAStatus{
+		Phase:    deployv1beta1.RootCAPhaseActive,
+		NotAfter: metav1.NewTime(current.NotAfter),
+	}, nil
+}
+
+// create generates the root CA and stores it in a new secret.
+func create(
+	reconciler workload.Reconciler,
+	req *workload.Request,
+	namespace string,
+	spec deployv1beta1.RootCA,
+) (*deployv1beta1.RootCAStatus, error) {
+	data, err := generate(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := parseCertificate(data[corev1.TLSCertKey])
+	if err != nil {
+		return nil, err
+	}
+
+	data[BundleKey] = data[corev1.TLSCertKey]
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      SecretName,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/part-of":    "platform",
+				"app.kubernetes.io/managed-by": "platform-config-operator",
+			},
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: data,
+	}
+
+	if err := reconciler.Create(req.Context, secret); err != nil {
+		if !apierrs.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("unable to create root CA secret, %w", err)
+		}
+
+		// the secret was created since it was retrieved, so we use that one.
+		return Ensure(reconciler, req, namespace, spec)
+	}
+
+	req.Log.Info("created root CA", "notAfter", current.NotAfter)
+
+	return &deployv1beta1.RootCAStatus{
+		Phase:    deployv1beta1.RootCAPhaseActive,
+		NotAfter: metav1.NewTime(current.NotAfter),
+	}, nil
+}
+
+// reissue triggers the reissuance of each certificate from the ClusterIssuer of the root CA which
+// was not issued by the current root CA, returning the number of such certificates.
+func reissue(reconciler workload.Reconciler, req *workload.Request, current *x509.Certificate) (int, error) {
+	certificates := &unstructured.UnstructuredList{}
+	certificates.SetGroupVersionKind(certificateGVK)
+
+	if err := reconciler.GetManager().GetAPIReader().List(req.Context, certificates); err != nil {
+		if meta.IsNoMatchError(err) {
+			return 0, nil
+		}
+
+		return 0, fmt.Errorf("unable to list certificates, %w", err)
+	}
+
+	var pending int
+
+	for i := range certificates.Items {
+		certificate := &certificates.Items[i]
+
+		if !FromIssuer(certificate) {
+			continue
+		}
+
+		reissued, err := issuedBy(reconciler, req, certificate, current)
+		if err != nil {
+			return 0, err
+		}
+
+		if reissued {
+			continue
+		}
+
+		pending++
+
+		if err := renew(reconciler, req, certificate); err != nil {
+			return 0, err
+		}
+	}
+
+	return pending, nil
+}
+
+// FromIssuer determines if a certificate is issued by the ClusterIssuer of the root CA.
+func FromIssuer(object client.Object) bool {
+	certificate, ok := object.(*unstructured.Unstructured)
+	if !ok {
+		return false
+	}
+
+	issuerRef, _, _ := unstructured.NestedStringMap(certificate.Object, "spec", "issuerRef")
+
+	return issuerRef["name"] == IssuerName &&
+		issuerRef["kind"] == "ClusterIssuer" &&
+		(issuerRef["group"] == "" || issuerRef["group"] == "cert-manager.io")
+}
+
+// issuedBy determines if the secret of a certificate holds a certificate which was issued by the
+// current root CA.
+func issuedBy(
+	reconciler workload.Reconciler,
+	req *workload.Request,
+	certificate *unstructured.Unstructured,
+	current *x509.Certificate,
+) (bool, error) {
+	secretName, _, _ := unstructured.NestedString(certificate.Object, "spec", "secretName")
+
+	secret := &corev1.Secret{}
+
+	if err := reconciler.GetManager().GetAPIReader().Get(
+		req.Context,
+		client.ObjectKey{Namespace: certificate.GetNamespace(), Name: secretName},
+		secret,
+	); err != nil {
+		if apierrs.IsNotFound(err) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("unable to retrieve secret of certificate %s/%s, %w", certificate.GetNamespace(), certificate.GetName(), err)
+	}
+
+	// a certificate which cannot be parsed is treated as not yet reissued
+	leaf, err := parseCertificate(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return false, nil
+	}
+
+	return leaf.CheckSignatureFrom(current) == nil, nil
+}
+
+// renew triggers the reissuance of a certificate, as is done by the renew command of cmctl, unless
+// it is already being issued.
+func renew(reconciler workload.Reconciler, req *workload.Request, certificate *unstructured.Unstructured) error {
+	conditions, _, _ := unstructured.NestedSlice(certificate.Object, "status", "conditions")
+
+	for _, condition := range conditions {
+		fields, ok := condition.(map[string]interface{})
+		if ok && fields["type"] == "Issuing" && fields["status"] == "True" {
+			return nil
+		}
+	}
+
+	conditions = append(conditions, map[string]interface{}{
+		"type":               "Issuing",
+		"status":             "True",
+		"reason":             "ManuallyTriggered",
+		"message":            "Certificate re-issuance triggered by rotation of the platform root CA",
+		"lastTransitionTime": time.Now().UTC().Format(time.RFC3339),
+	})
+
+	if err := unstructured.SetNestedSlice(certificate.Object, conditions, "status", "conditions"); err != nil {
+		return fmt.Errorf("unable to set conditions of certificate %s/%s, %w", certificate.GetNamespace(), certificate.GetName(), err)
+	}
+
+	if err := reconciler.Status().Update(req.Context, certificate); err != nil {
+		if apierrs.IsConflict(err) || apierrs.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("unable to renew certificate %s/%s, %w", certificate.GetNamespace(), certificate.GetName(), err)
+	}
+
+	req.Log.Info("triggered reissuance of certificate", "namespace", certificate.GetNamespace(), "name", certificate.GetName())
+
+	return nil
+}
+
+// trusted returns the unexpired certificates of a PEM encoded bundle, with the current root CA first.
+func trusted(bundlePEM []byte, current *x509.Certificate) []*x509.Certificate {
+	certificates := []*x509.Certificate{current}
+	now := time.Now()
+
+	for block, rest := pem.Decode(bundlePEM); block != nil; block, rest = pem.Decode(rest) {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil || now.After(cert.NotAfter) || cert.Equal(current) {
+			continue
+		}
+
+		duplicate := false
+		for _, existing := range certificates {
+			duplicate = duplicate || existing.Equal(cert)
+		}
+
+		if !duplicate {
+			certificates = append(certificates, cert)
+		}
+	}
+
+	return certificates
+}
+
+// encode returns the PEM encoded bundle of certificates.
+func encode(certificates []*x509.Certificate) []byte {
+	var bundle bytes.Buffer
+
+	for _, cert := range certificates {
+		_ = pem.Encode(&bundle, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	}
+
+	return bundle.Bytes()
+}
+
+// parseCertificate parses the first certificate of a PEM encoded chain.
+func parseCertificate(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, ErrMissingCertificate
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse root CA certificate, %w", err)
+	}
+
+	return cert, nil
+}
+
+// generate generates a self-signed root CA.
+func generate(spec deployv1beta1.RootCA) (map[string][]byte, error) {
+	key, err := generateKey(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	template := &x509.Certificate{
+		SerialNumber: serialNumber(),
+		Subject: pkix.Name{
+			CommonName:         spec.Subject.CommonName,
+			Organization:       spec.Subject.Organizations,
+			OrganizationalUnit: spec.Subject.OrganizationalUnits,
+			Country:            spec.Subject.Countries,
+		},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(spec.Validity.Duration),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create root CA certificate, %w", err)
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal root CA private key, %w", err)
+	}
+
+	return map[string][]byte{
+		corev1.TLSCertKey:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		corev1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}),
+	}, nil
+}
+
+// generateKey generates the private key of a root CA.
+func generateKey(spec deployv1beta1.RootCA) (crypto.Signer, error) {
+	switch spec.KeyAlgorithm {
+	case "ECDSA":
+		var curve elliptic.Curve
+
+		switch spec.KeySize {
+		case 256:
+			curve = elliptic.P256()
+		case 384:
+			curve = elliptic.P384()
+		default:
+			return nil, fmt.Errorf("%w %d", ErrUnsupportedECDSAKeySize, spec.KeySize)
+		}
+
+		key, err := ecdsa.GenerateKey(curve, rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("unable to generate root CA private key, %w", err)
+		}
+
+		return key, nil
+	case "RSA":
+		key, err := rsa.GenerateKey(rand.Reader, spec.KeySize)
+		if err != nil {
+			return nil, fmt.Errorf("unable to generate root CA private key, %w", err)
+		}
+
+		return key, nil
+	default:
+		return nil, fmt.Errorf("%w %s", ErrUnsupportedKeyAlgorithm, spec.KeyAlgorithm)
+	}
+}
+
+// serialNumber returns a random serial number for a certificate.
+func serialNumber() *big.Int {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return big.NewInt(time.Now().UnixNano())
+	}
+
+	return serial
+}
@@ -0,0 +1,413 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rootca
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+)
+
+const namespace = "tbd-certificates-system"
+
+var certificateKind = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+
+type fakeManager struct {
+	manager.Manager
+
+	client client.Client
+}
+
+func (m *fakeManager) GetAPIReader() client.Reader { return m.client }
+
+type fakeReconciler struct {
+	workload.Reconciler
+
+	client client.Client
+}
+
+func (r *fakeReconciler) GetManager() manager.Manager { return &fakeManager{client: r.client} }
+
+func (r *fakeReconciler) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	return r.client.Create(ctx, obj, opts...)
+}
+
+func (r *fakeReconciler) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return r.client.Update(ctx, obj, opts...)
+}
+
+func (r *fakeReconciler) Status() client.SubResourceWriter {
+	return r.client.Status()
+}
+
+// testSpec returns the configuration of a root CA which is valid for a duration and renewed an
+// hour before it expires.
+func testSpec(validity time.Duration) deployv1beta1.RootCA {
+	return deployv1beta1.RootCA{
+		KeyAlgorithm: "ECDSA",
+		KeySize:      256,
+		Validity:     metav1.Duration{Duration: validity},
+		RenewBefore:  metav1.Duration{Duration: time.Hour},
+		Subject:      deployv1beta1.RootCASubject{CommonName: "platform-root-ca"},
+	}
+}
+
+// testCA generates the data of a root CA secret which expires after a duration, or which has
+// already expired when the duration is negative.
+func testCA(t *testing.T, validity time.Duration) map[string][]byte {
+	t.Helper()
+
+	spec := testSpec(validity)
+	spec.RenewBefore = metav1.Duration{}
+
+	data, err := generate(spec)
+	require.NoError(t, err)
+
+	data[BundleKey] = data[corev1.TLSCertKey]
+
+	return data
+}
+
+// rootCASecret returns the root CA secret, trusting the CA of each of the bundles.
+func rootCASecret(data map[string][]byte, bundles ...map[string][]byte) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: SecretName, Namespace: namespace},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       data[corev1.TLSCertKey],
+			corev1.TLSPrivateKeyKey: data[corev1.TLSPrivateKeyKey],
+			BundleKey:               data[BundleKey],
+		},
+	}
+
+	for _, bundle := range bundles {
+		secret.Data[BundleKey] = append(append([]byte{}, secret.Data[BundleKey]...), bundle[corev1.TLSCertKey]...)
+	}
+
+	return secret
+}
+
+// leafSecret returns the secret of a certificate which holds a leaf certificate issued by a root CA.
+func leafSecret(t *testing.T, name string, ca map[string][]byte) *corev1.Secret {
+	t.Helper()
+
+	caCert, err := parseCertificate(ca[corev1.TLSCertKey])
+	require.NoError(t, err)
+
+	block, _ := pem.Decode(ca[corev1.TLSPrivateKeyKey])
+	require.NotNil(t, block)
+
+	caKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	require.NoError(t, err)
+
+	key, err := generateKey(testSpec(time.Hour))
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: serialNumber(),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, key.Public(), caKey.(crypto.Signer))
+	require.NoError(t, err)
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "tenant"},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		},
+	}
+}
+
+// certificate returns a cert-manager Certificate which is issued by a ClusterIssuer into a secret
+// of the same name.
+func certificate(name, issuer string) *unstructured.Unstructured {
+	object := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"secretName": name,
+			"issuerRef": map[string]interface{}{
+				"name":  issuer,
+				"kind":  "ClusterIssuer",
+				"group": "cert-manager.io",
+			},
+		},
+	}}
+
+	object.SetGroupVersionKind(certificateKind)
+	object.SetName(name)
+	object.SetNamespace("tenant")
+
+	return object
+}
+
+// issuing determines if the reissuance of a certificate has been triggered.
+func issuing(t *testing.T, c client.Client, name string) bool {
+	t.Helper()
+
+	object := &unstructured.Unstructured{}
+	object.SetGroupVersionKind(certificateKind)
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "tenant", Name: name}, object))
+
+	conditions, _, err := unstructured.NestedSlice(object.Object, "status", "conditions")
+	require.NoError(t, err)
+
+	for _, condition := range conditions {
+		fields, ok := condition.(map[string]interface{})
+		if ok && fields["type"] == "Issuing" && fields["status"] == "True" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parse parses the certificate of the data of a root CA secret.
+func parse(t *testing.T, data map[string][]byte) *x509.Certificate {
+	t.Helper()
+
+	cert, err := parseCertificate(data[corev1.TLSCertKey])
+	require.NoError(t, err)
+
+	return cert
+}
+
+// bundleOf parses each certificate of the trust bundle of the root CA secret.
+func bundleOf(t *testing.T, secret *corev1.Secret) []*x509.Certificate {
+	t.Helper()
+
+	certificates := []*x509.Certificate{}
+
+	for block, rest := pem.Decode(secret.Data[BundleKey]); block != nil; block, rest = pem.Decode(rest) {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		require.NoError(t, err)
+
+		certificates = append(certificates, cert)
+	}
+
+	return certificates
+}
+
+func TestEnsure(t *testing.T) {
+	t.Parallel()
+
+	current := testCA(t, 24*time.Hour)
+	previous := testCA(t, 24*time.Hour)
+	expiring := testCA(t, 30*time.Minute)
+
+	for _, tt := range []struct {
+		name    string
+		objects []client.Object
+
+		phase   string
+		pending int
+
+		// rotated determines if the root CA is replaced, and trusted is the number of root CAs which
+		// are trusted afterwards
+		rotated bool
+		trusted int
+
+		// previous is the root CA which remains trusted alongside the current root CA
+		previous map[string][]byte
+
+		// issuing are the certificates whose reissuance is triggered
+		issuing []string
+	}{
+		{
+			name:    "create",
+			phase:   deployv1beta1.RootCAPhaseActive,
+			rotated: true,
+			trusted: 1,
+		},
+		{
+			name:    "no-op",
+			objects: []client.Object{rootCASecret(current)},
+			phase:   deployv1beta1.RootCAPhaseActive,
+			trusted: 1,
+		},
+		{
+			name: "rotate",
+			objects: []client.Object{
+				rootCASecret(expiring),
+				certificate("web", IssuerName),
+				leafSecret(t, "web", expiring),
+			},
+			phase:    deployv1beta1.RootCAPhaseRotating,
+			pending:  1,
+			rotated:  true,
+			trusted:  2,
+			previous: expiring,
+			issuing:  []string{"web"},
+		},
+		{
+			name: "overlap",
+			objects: []client.Object{
+				rootCASecret(current, previous),
+				certificate("web", IssuerName),
+				leafSecret(t, "web", previous),
+				certificate("api", IssuerName),
+				leafSecret(t, "api", current),
+			},
+			phase:    deployv1beta1.RootCAPhaseRotating,
+			pending:  1,
+			trusted:  2,
+			previous: previous,
+			issuing:  []string{"web"},
+		},
+		{
+			name: "end of overlap",
+			objects: []client.Object{
+				rootCASecret(current, previous),
+				certificate("web", IssuerName),
+				leafSecret(t, "web", current),
+			},
+			phase:   deployv1beta1.RootCAPhaseActive,
+			trusted: 1,
+		},
+		{
+			name: "other issuers",
+			objects: []client.Object{
+				rootCASecret(current, previous),
+				certificate("web", "letsencrypt"),
+				leafSecret(t, "web", previous),
+			},
+			phase:   deployv1beta1.RootCAPhaseActive,
+			trusted: 1,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			scheme := runtime.NewScheme()
+			require.NoError(t, clientgoscheme.AddToScheme(scheme))
+
+			mapper := meta.NewDefaultRESTMapper(nil)
+			mapper.Add(corev1.SchemeGroupVersion.WithKind("Secret"), meta.RESTScopeNamespace)
+			mapper.Add(certificateKind, meta.RESTScopeNamespace)
+
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRESTMapper(mapper).
+				WithObjects(tt.objects...).
+				WithStatusSubresource(certificate("", "")).
+				Build()
+
+			r := &fakeReconciler{client: c}
+			req := &workload.Request{Context: context.Background(), Log: logr.Discard()}
+
+			status, err := Ensure(r, req, namespace, testSpec(24*time.Hour))
+			require.NoError(t, err)
+			require.Equal(t, tt.phase, status.Phase)
+			require.Equal(t, tt.pending, status.PendingCertificates)
+
+			secret := &corev1.Secret{}
+			require.NoError(t, c.Get(req.Context, client.ObjectKey{Namespace: namespace, Name: SecretName}, secret))
+
+			root := parse(t, secret.Data)
+			require.Equal(t, metav1.NewTime(root.NotAfter), status.NotAfter)
+
+			// the current root CA is always trusted first
+			bundle := bundleOf(t, secret)
+			require.Len(t, bundle, tt.trusted)
+			require.True(t, bundle[0].Equal(root))
+
+			if !tt.rotated {
+				require.Equal(t, tt.objects[0].(*corev1.Secret).Data[corev1.TLSCertKey], secret.Data[corev1.TLSCertKey])
+			} else if len(tt.objects) > 0 {
+				require.NotEqual(t, tt.objects[0].(*corev1.Secret).Data[corev1.TLSCertKey], secret.Data[corev1.TLSCertKey])
+			}
+
+			// the previous root CA is trusted until every certificate is reissued
+			if tt.previous != nil {
+				require.Len(t, bundle, 2)
+				require.True(t, bundle[1].Equal(parse(t, tt.previous)))
+			}
+
+			for _, object := range tt.objects {
+				if object.GetObjectKind().GroupVersionKind() != certificateKind {
+					continue
+				}
+
+				expected := false
+				for _, name := range tt.issuing {
+					expected = expected || name == object.GetName()
+				}
+
+				require.Equal(t, expected, issuing(t, c, object.GetName()), object.GetName())
+			}
+		})
+	}
+}
+
+func TestEnsureRejectsRenewalAfterExpiry(t *testing.T) {
+	t.Parallel()
+
+	spec := testSpec(time.Hour)
+	spec.RenewBefore = metav1.Duration{Duration: 2 * time.Hour}
+
+	_, err := Ensure(&fakeReconciler{}, &workload.Request{Context: context.Background()}, namespace, spec)
+	require.ErrorIs(t, err, ErrInvalidCertificateConfig)
+}
+
+func TestTrusted(t *testing.T) {
+	t.Parallel()
+
+	current := parse(t, testCA(t, 24*time.Hour))
+	previous := parse(t, testCA(t, 24*time.Hour))
+	expired := parse(t, testCA(t, -time.Minute))
+
+	for _, tt := range []struct {
+		name     string
+		bundle   []*x509.Certificate
+		expected []*x509.Certificate
+	}{
+		{name: "empty", bundle: nil, expected: []*x509.Certificate{current}},
+		{name: "current only", bundle: []*x509.Certificate{current}, expected: []*x509.Certificate{current}},
+		{name: "current first", bundle: []*x509.Certificate{previous, current}, expected: []*x509.Certificate{current, previous}},
+		{name: "duplicates", bundle: []*x509.Certificate{previous, previous}, expected: []*x509.Certificate{current, previous}},
+		{name: "expired", bundle: []*x509.Certificate{current, expired, previous}, expected: []*x509.Certificate{current, previous}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, encode(tt.expected), encode(trusted(encode(tt.bundle), current)))
+		})
+	}
+}
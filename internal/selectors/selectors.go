@@ -0,0 +1,88 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selectors
+
+import (
+	"fmt"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;delete
+
+// Replace deletes a workload from the cluster when its selector differs from the desired selector,
+// so that it is created again with the desired selector, as the selector of a workload is
+// immutable and updating it would otherwise fail.  This happens when the labels which a workload
+// selects its pods by change between releases.
+func Replace(reconciler workload.Reconciler, req *workload.Request, desired client.Object) error {
+	if reconciler == nil || req == nil {
+		return nil
+	}
+
+	desiredObject, ok := desired.(*unstructured.Unstructured)
+	if !ok {
+		return nil
+	}
+
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(desiredObject.GroupVersionKind())
+
+	if err := reconciler.Get(req.Context, client.ObjectKeyFromObject(desired), current); err != nil {
+		if apierrs.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("unable to retrieve %s %s, %w", desiredObject.GetKind(), desired.GetName(), err)
+	}
+
+	desiredSelector, _, err := unstructured.NestedFieldNoCopy(desiredObject.Object, "spec", "selector")
+	if err != nil {
+		return fmt.Errorf("unable to read selector of %s, %w", desired.GetName(), err)
+	}
+
+	currentSelector, _, err := unstructured.NestedFieldNoCopy(current.Object, "spec", "selector")
+	if err != nil {
+		return fmt.Errorf("unable to read selector of %s, %w", desired.GetName(), err)
+	}
+
+	if desiredSelector == nil || equality.Semantic.DeepEqual(desiredSelector, currentSelector) {
+		return nil
+	}
+
+	req.Log.Info(
+		"replacing workload with changed selector",
+		"kind", desiredObject.GetKind(),
+		"name", desired.GetName(),
+		"namespace", desired.GetNamespace(),
+	)
+
+	if err := reconciler.Delete(
+		req.Context,
+		current,
+		client.PropagationPolicy("Background"),
+		client.Preconditions{UID: ptr.To(current.GetUID())},
+	); err != nil && !apierrs.IsNotFound(err) {
+		return fmt.Errorf("unable to delete %s %s, %w", desiredObject.GetKind(), desired.GetName(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selectors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type fakeReconciler struct {
+	workload.Reconciler
+
+	client client.Client
+}
+
+func (r *fakeReconciler) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return r.client.Get(ctx, key, obj, opts...)
+}
+
+func (r *fakeReconciler) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	return r.client.Delete(ctx, obj, opts...)
+}
+
+func desired(name string, labels map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": "platform",
+			},
+			"spec": map[string]interface{}{
+				"selector": map[string]interface{}{"matchLabels": labels},
+			},
+		},
+	}
+}
+
+func TestReplace(t *testing.T) {
+	t.Parallel()
+
+	current := func(name string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "platform"},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": name, "version": "v1"}},
+			},
+		}
+	}
+
+	r := &fakeReconciler{
+		client: fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(
+			current("unchanged"),
+			current("changed"),
+		).Build(),
+	}
+
+	req := &workload.Request{Context: context.Background(), Log: logr.Discard()}
+
+	// a deployment whose selector is unchanged is kept
+	require.NoError(t, Replace(r, req, desired("unchanged", map[string]interface{}{"app": "unchanged", "version": "v1"})))
+	require.NoError(t, r.client.Get(req.Context, client.ObjectKey{Namespace: "platform", Name: "unchanged"}, &appsv1.Deployment{}))
+
+	// a deployment whose selector changed is deleted, so that it is created again
+	require.NoError(t, Replace(r, req, desired("changed", map[string]interface{}{"app": "changed"})))
+
+	err := r.client.Get(req.Context, client.ObjectKey{Namespace: "platform", Name: "changed"}, &appsv1.Deployment{})
+	require.True(t, apierrs.IsNotFound(err))
+
+	// a deployment which does not exist has nothing to replace
+	require.NoError(t, Replace(r, req, desired("missing", map[string]interface{}{"app": "missing"})))
+
+	// nothing is replaced when generating resources from the command line
+	require.NoError(t, Replace(nil, nil, desired("unchanged", map[string]interface{}{"app": "unchanged"})))
+}
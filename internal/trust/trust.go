@@ -0,0 +1,263 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trust
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+	"github.com/tbd-paas/platform-config-operator/internal/readiness"
+	"github.com/tbd-paas/platform-config-operator/internal/rootca"
+)
+
+// +kubebuilder:rbac:groups=trust.cert-manager.io,resources=bundles,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch;patch
+
+const (
+	Group   = "trust.cert-manager.io"
+	Version = "v1alpha1"
+	Kind    = "Bundle"
+
+	// InjectLabel is the label of the namespaces of the platform components, which are selected for
+	// injection by default.
+	InjectLabel = "certificates.platform.tbd.io/inject-ca-bundle"
+
+	// TargetLabel is the label which is managed by the operator on the namespaces which are selected
+	// for injection, and is the target of the bundles which do not select namespaces themselves.
+	TargetLabel = "certificates.platform.tbd.io/ca-bundle-target"
+)
+
+// trustManagerName is the name of the TrustManager resource of the certificates capability.
+const trustManagerName = "config"
+
+var trustManagerGVK = schema.GroupVersionKind{
+	Group:   "certificates.platform.tbd.io",
+	Version: "v1alpha1",
+	Kind:    "TrustManager",
+}
+
+// Generate returns the Bundles of the certificates capability.  The private root CA is only a
+// source of a bundle when the cloud is local, as it does not exist otherwise, and a bundle without
+// any sources is not generated.
+func Generate(
+	spec deployv1beta1.PlatformConfigSpecCertificates,
+	local bool,
+	labels map[string]interface{},
+) []client.Object {
+	objects := make([]client.Object, 0, len(spec.Trust.Bundles))
+
+	for _, bundle := range spec.Trust.Bundles {
+		// a bundle whose only source is the private root CA has no sources when the cloud is not local
+		bundleSources := sources(bundle.Sources, local)
+		if len(bundleSources) == 0 {
+			continue
+		}
+
+		copied := make(map[string]interface{}, len(labels))
+		for key, value := range labels {
+			copied[key] = value
+		}
+
+		objects = append(objects, &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": Group + "/" + Version,
+				"kind":       Kind,
+				"metadata": map[string]interface{}{
+					"name":   bundle.Name,
+					"labels": copied,
+				},
+				"spec": map[string]interface{}{
+					"sources": bundleSources,
+					"target":  target(bundle.Target),
+				},
+			},
+		})
+	}
+
+	return objects
+}
+
+// sources returns the sources of a Bundle.
+func sources(spec deployv1beta1.TrustBundleSources, local bool) []interface{} {
+	result := []interface{}{}
+
+	if spec.UseDefaultCAs {
+		result = append(result, map[string]interface{}{
+			"useDefaultCAs": true,
+		})
+	}
+
+	if spec.PlatformRootCA && local {
+		result = append(result, map[string]interface{}{
+			"secret": map[string]interface{}{
+				"name": rootca.SecretName,
+				"key":  rootca.BundleKey,
+			},
+		})
+	}
+
+	for _, secret := range spec.Secrets {
+		result = append(result, map[string]interface{}{
+			"secret": map[string]interface{}{
+				"name": secret.Name,
+				"key":  secret.Key,
+			},
+		})
+	}
+
+	for _, configMap := range spec.ConfigMaps {
+		result = append(result, map[string]interface{}{
+			"configMap": map[string]interface{}{
+				"name": configMap.Name,
+				"key":  configMap.Key,
+			},
+		})
+	}
+
+	return result
+}
+
+// target returns the target of a Bundle.
+func target(spec deployv1beta1.TrustBundleTarget) map[string]interface{} {
+	matchLabels := map[string]interface{}{
+		TargetLabel: "true",
+	}
+
+	if len(spec.NamespaceSelector) > 0 {
+		matchLabels = make(map[string]interface{}, len(spec.NamespaceSelector))
+		for key, value := range spec.NamespaceSelector {
+			matchLabels[key] = value
+		}
+	}
+
+	kind := "configMap"
+	if spec.Kind == "Secret" {
+		kind = "secret"
+	}
+
+	return map[string]interface{}{
+		kind: map[string]interface{}{
+			"key": spec.Key,
+		},
+		"namespaceSelector": map[string]interface{}{
+			"matchLabels": matchLabels,
+		},
+	}
+}
+
+// Ready determines if the Bundles may be created, which is once the TrustManager resource reports
+// that trust-manager is ready.
+func Ready(reconciler workload.Reconciler, req *workload.Request) (bool, error) {
+	return readiness.Capability(reconciler, req, trustManagerGVK, trustManagerName, schema.GroupKind{Group: Group, Kind: Kind})
+}
+
+// Injected determines if any of the bundles are written to the namespaces which are selected for
+// injection, rather than selecting namespaces themselves.
+func Injected(bundles []deployv1beta1.TrustBundle) bool {
+	for _, bundle := range bundles {
+		if len(bundle.Target.NamespaceSelector) == 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Inject labels the namespaces which are selected for injection with the target label, and removes
+// the label from those which are not, returning the names of the selected namespaces.  No namespaces
+// are selected when injection is not enabled.
+func Inject(
+	reconciler workload.Reconciler,
+	req *workload.Request,
+	spec deployv1beta1.TrustInjection,
+	enabled bool,
+) ([]string, error) {
+	namespaces := &corev1.NamespaceList{}
+	if err := reconciler.List(req.Context, namespaces); err != nil {
+		return nil, fmt.Errorf("unable to list namespaces, %w", err)
+	}
+
+	selector := labels.SelectorFromSet(spec.NamespaceSelector)
+	selected := []string{}
+
+	for i := range namespaces.Items {
+		namespace := &namespaces.Items[i]
+
+		want := enabled && isSelected(namespace, selector, spec)
+		has := namespace.GetLabels()[TargetLabel] == "true"
+
+		if want {
+			selected = append(selected, namespace.Name)
+		}
+
+		if want == has {
+			continue
+		}
+
+		original := namespace.DeepCopy()
+
+		if want {
+			namespace.SetLabels(labels.Merge(namespace.GetLabels(), labels.Set{TargetLabel: "true"}))
+		} else {
+			delete(namespace.Labels, TargetLabel)
+		}
+
+		if err := reconciler.Patch(req.Context, namespace, client.MergeFrom(original)); err != nil {
+			return nil, fmt.Errorf("unable to label namespace %s, %w", namespace.Name, err)
+		}
+	}
+
+	sort.Strings(selected)
+
+	return selected, nil
+}
+
+// Eject removes the target label from every namespace, so that no namespace is left selected for
+// injection once the workload which labeled it is deleted.
+func Eject(reconciler workload.Reconciler, req *workload.Request) error {
+	_, err := Inject(reconciler, req, deployv1beta1.TrustInjection{}, false)
+
+	return err
+}
+
+// isSelected determines if a namespace is selected for injection.
+func isSelected(namespace *corev1.Namespace, selector labels.Selector, spec deployv1beta1.TrustInjection) bool {
+	if !namespace.DeletionTimestamp.IsZero() || contains(spec.ExcludeNamespaces, namespace.Name) {
+		return false
+	}
+
+	return contains(spec.IncludeNamespaces, namespace.Name) || selector.Matches(labels.Set(namespace.GetLabels()))
+}
+
+// contains determines if a list of names contains a name.
+func contains(names []string, name string) bool {
+	for _, candidate := range names {
+		if candidate == name {
+			return true
+		}
+	}
+
+	return false
+}
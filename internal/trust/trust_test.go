@@ -0,0 +1,180 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trust
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+	"github.com/tbd-paas/platform-config-operator/internal/rootca"
+)
+
+type fakeReconciler struct {
+	workload.Reconciler
+
+	client client.Client
+}
+
+func (r *fakeReconciler) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return r.client.List(ctx, list, opts...)
+}
+
+func (r *fakeReconciler) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return r.client.Patch(ctx, obj, patch, opts...)
+}
+
+func namespace(name string, labels map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func targeted(t *testing.T, r *fakeReconciler) []string {
+	t.Helper()
+
+	namespaces := &corev1.NamespaceList{}
+	require.NoError(t, r.client.List(context.Background(), namespaces, client.MatchingLabels{TargetLabel: "true"}))
+
+	names := []string{}
+	for _, namespace := range namespaces.Items {
+		names = append(names, namespace.Name)
+	}
+
+	return names
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	spec := deployv1beta1.PlatformConfigSpecCertificates{
+		Trust: deployv1beta1.Trust{
+			Bundles: []deployv1beta1.TrustBundle{
+				{
+					Name:    "platform",
+					Sources: deployv1beta1.TrustBundleSources{PlatformRootCA: true},
+					Target:  deployv1beta1.TrustBundleTarget{Kind: "ConfigMap", Key: "ca-bundle.crt"},
+				},
+				{
+					Name: "combined",
+					Sources: deployv1beta1.TrustBundleSources{
+						UseDefaultCAs:  true,
+						PlatformRootCA: true,
+						Secrets:        []deployv1beta1.TrustBundleSource{{Name: "corporate", Key: "ca.crt"}},
+						ConfigMaps:     []deployv1beta1.TrustBundleSource{{Name: "partner", Key: "ca.pem"}},
+					},
+					Target: deployv1beta1.TrustBundleTarget{
+						Kind:              "Secret",
+						Key:               "bundle.pem",
+						NamespaceSelector: map[string]string{"team": "payments"},
+					},
+				},
+			},
+		},
+	}
+
+	labels := map[string]interface{}{"app.kubernetes.io/part-of": "platform"}
+
+	specOf := func(object *unstructured.Unstructured) map[string]interface{} {
+		require.Equal(t, Group+"/"+Version, object.GetAPIVersion())
+		require.Equal(t, Kind, object.GetKind())
+		require.Equal(t, "platform", object.GetLabels()["app.kubernetes.io/part-of"])
+
+		fields, _, err := unstructured.NestedMap(object.Object, "spec")
+		require.NoError(t, err)
+
+		return fields
+	}
+
+	objects := Generate(spec, true, labels)
+	require.Len(t, objects, 2)
+
+	platform := specOf(objects[0].(*unstructured.Unstructured))
+	require.Equal(t, []interface{}{
+		map[string]interface{}{"secret": map[string]interface{}{"name": rootca.SecretName, "key": rootca.BundleKey}},
+	}, platform["sources"])
+	require.Equal(t, map[string]interface{}{
+		"configMap":         map[string]interface{}{"key": "ca-bundle.crt"},
+		"namespaceSelector": map[string]interface{}{"matchLabels": map[string]interface{}{TargetLabel: "true"}},
+	}, platform["target"])
+
+	combined := specOf(objects[1].(*unstructured.Unstructured))
+	require.Equal(t, []interface{}{
+		map[string]interface{}{"useDefaultCAs": true},
+		map[string]interface{}{"secret": map[string]interface{}{"name": rootca.SecretName, "key": rootca.BundleKey}},
+		map[string]interface{}{"secret": map[string]interface{}{"name": "corporate", "key": "ca.crt"}},
+		map[string]interface{}{"configMap": map[string]interface{}{"name": "partner", "key": "ca.pem"}},
+	}, combined["sources"])
+	require.Equal(t, map[string]interface{}{
+		"secret":            map[string]interface{}{"key": "bundle.pem"},
+		"namespaceSelector": map[string]interface{}{"matchLabels": map[string]interface{}{"team": "payments"}},
+	}, combined["target"])
+
+	// the private root CA only exists on a local cloud, so a bundle of only the root CA is dropped
+	objects = Generate(spec, false, labels)
+	require.Len(t, objects, 1)
+	require.Equal(t, "combined", objects[0].GetName())
+	require.Len(t, specOf(objects[0].(*unstructured.Unstructured))["sources"], 3)
+}
+
+func TestInjectAndEject(t *testing.T) {
+	t.Parallel()
+
+	r := &fakeReconciler{
+		client: fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(
+			namespace("tbd-operators-system", map[string]string{InjectLabel: "true"}),
+			namespace("tbd-identity-system", map[string]string{InjectLabel: "true"}),
+			namespace("excluded", map[string]string{InjectLabel: "true"}),
+			namespace("tenant", nil),
+			namespace("stale", map[string]string{TargetLabel: "true"}),
+		).Build(),
+	}
+
+	req := &workload.Request{Context: context.Background(), Log: logr.Discard()}
+
+	spec := deployv1beta1.TrustInjection{
+		NamespaceSelector: map[string]string{InjectLabel: "true"},
+		IncludeNamespaces: []string{"tenant"},
+		ExcludeNamespaces: []string{"excluded"},
+	}
+
+	// selected namespaces are labeled and the label is removed from those which are not
+	injected, err := Inject(r, req, spec, true)
+	require.NoError(t, err)
+	require.Equal(t, []string{"tbd-identity-system", "tbd-operators-system", "tenant"}, injected)
+	require.ElementsMatch(t, injected, targeted(t, r))
+
+	// no namespace is selected when injection is not enabled
+	injected, err = Inject(r, req, spec, false)
+	require.NoError(t, err)
+	require.Empty(t, injected)
+	require.Empty(t, targeted(t, r))
+
+	// the label is removed from every namespace, including those of the platform components
+	_, err = Inject(r, req, spec, true)
+	require.NoError(t, err)
+	require.NoError(t, Eject(r, req))
+	require.Empty(t, targeted(t, r))
+}
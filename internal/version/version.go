@@ -0,0 +1,29 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package version
+
+// The versions of this build, which are stamped upon the child resources of the workloads.  They
+// are set at build time using linker flags, for example:
+//
+//	go build -ldflags "-X github.com/tbd-paas/platform-config-operator/internal/version.Version=v0.1.0"
+var (
+	// Version is the version of the platform release which this build belongs to.
+	Version = "unstable"
+
+	// CapabilityVersion is the version of the platform-config capability.
+	CapabilityVersion = "v0.0.1"
+)
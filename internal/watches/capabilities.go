@@ -0,0 +1,43 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watches
+
+import (
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Capabilities requeues every workload of a kind when the status of the custom resource of a
+// capability changes, for workloads whose children are only created once a capability which they
+// do not own is ready.  As with child resources, capabilities whose kind is not yet served by the
+// cluster are skipped and are picked up on a subsequent reconciliation.  The workloads are listed
+// into a new instance of list for each event.
+func Capabilities(
+	r workload.Reconciler,
+	req *workload.Request,
+	list func() client.ObjectList,
+	capabilities ...schema.GroupVersionKind,
+) error {
+	for _, gvk := range capabilities {
+		if err := watchStatus(r, req, list, gvk, StatusChangedPredicate()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watches
+
+import (
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+var certificateGVK = schema.GroupVersionKind{
+	Group:   "cert-manager.io",
+	Version: "v1",
+	Kind:    "Certificate",
+}
+
+// Certificates requeues every workload of a kind when the status of a selected cert-manager
+// Certificate changes, for workloads which wait upon the issuance of certificates which they do not
+// own.  As with child resources, Certificates are only watched once cert-manager is installed.  The
+// workloads are listed into a new instance of list for each event.
+func Certificates(
+	r workload.Reconciler,
+	req *workload.Request,
+	list func() client.ObjectList,
+	selected func(client.Object) bool,
+) error {
+	return watchStatus(r, req, list, certificateGVK, predicate.NewPredicateFuncs(selected), StatusChangedPredicate())
+}
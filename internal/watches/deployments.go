@@ -0,0 +1,83 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watches
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// Deployments requeues every workload of a kind when a selected Deployment is created, deleted or
+// changes its spec, for workloads whose desired state depends upon Deployments which they do not
+// own.  The workloads are listed into a new instance of list for each event.
+func Deployments(
+	mgr manager.Manager,
+	c controller.Controller,
+	list func() client.ObjectList,
+	selected func(client.Object) bool,
+) error {
+	if err := c.Watch(
+		source.Kind(mgr.GetCache(), &appsv1.Deployment{}),
+		handler.EnqueueRequestsFromMapFunc(enqueueWorkloads(mgr, list, "deployment")),
+		predicate.NewPredicateFuncs(selected),
+		predicate.GenerationChangedPredicate{},
+	); err != nil {
+		return fmt.Errorf("unable to watch deployments, %w", err)
+	}
+
+	return nil
+}
+
+// enqueueWorkloads returns a function which maps an event of an object which is not owned by the
+// workloads to a request for every workload of a kind.
+func enqueueWorkloads(mgr manager.Manager, list func() client.ObjectList, kind string) handler.MapFunc {
+	return func(ctx context.Context, _ client.Object) []reconcile.Request {
+		workloads := list()
+		if err := mgr.GetClient().List(ctx, workloads); err != nil {
+			mgr.GetLogger().Error(err, "unable to list workloads for "+kind+" event")
+
+			return nil
+		}
+
+		objects, err := meta.ExtractList(workloads)
+		if err != nil {
+			mgr.GetLogger().Error(err, "unable to extract workloads for "+kind+" event")
+
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(objects))
+
+		for _, object := range objects {
+			if workload, ok := object.(client.Object); ok {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(workload)})
+			}
+		}
+
+		return requests
+	}
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watches
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// ServiceAccounts requeues every workload of a kind when a service account is created, deleted,
+// relabeled or reannotated, for workloads which annotate service accounts which they do not own.
+// The workloads are listed into a new instance of list for each event.
+func ServiceAccounts(mgr manager.Manager, c controller.Controller, list func() client.ObjectList) error {
+	if err := c.Watch(
+		source.Kind(mgr.GetCache(), &corev1.ServiceAccount{}),
+		handler.EnqueueRequestsFromMapFunc(enqueueWorkloads(mgr, list, "service account")),
+		predicate.Or(predicate.LabelChangedPredicate{}, predicate.AnnotationChangedPredicate{}),
+	); err != nil {
+		return fmt.Errorf("unable to watch service accounts, %w", err)
+	}
+
+	return nil
+}
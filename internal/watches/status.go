@@ -0,0 +1,129 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watches
+
+import (
+	"fmt"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// statusWatchAnnotation marks the objects which record a watch on the status of a kind, so that
+// they are not mistaken for the watch on a child resource of the same kind.
+const statusWatchAnnotation = "watches.platform.tbd.io/status"
+
+// watchStatus ensures that the reconciler is watching the status of a kind which its workloads do
+// not own, requeuing every workload of the reconciler on events which pass the predicates.  Kinds
+// which are not yet served by the cluster are skipped and are picked up on a subsequent
+// reconciliation.
+func watchStatus(
+	r workload.Reconciler,
+	req *workload.Request,
+	list func() client.ObjectList,
+	gvk schema.GroupVersionKind,
+	predicates ...predicate.Predicate,
+) error {
+	if isWatchedStatus(r, gvk) {
+		return nil
+	}
+
+	served, err := isServed(r, gvk)
+	if err != nil {
+		return err
+	}
+
+	if !served {
+		req.Log.V(2).Info(
+			"skipping watch for status of kind which is not yet served",
+			"group", gvk.Group,
+			"version", gvk.Version,
+			"kind", gvk.Kind,
+		)
+
+		return nil
+	}
+
+	watched := &unstructured.Unstructured{}
+	watched.SetGroupVersionKind(gvk)
+	watched.SetAnnotations(map[string]string{statusWatchAnnotation: "true"})
+
+	if err := r.GetController().Watch(
+		source.Kind(r.GetManager().GetCache(), watched),
+		handler.EnqueueRequestsFromMapFunc(enqueueWorkloads(r.GetManager(), list, gvk.Kind)),
+		predicates...,
+	); err != nil {
+		return fmt.Errorf("unable to watch status of kind %s, %w", gvk, err)
+	}
+
+	r.SetWatch(watched)
+
+	return nil
+}
+
+// StatusChangedPredicate returns the filter which passes only the updates which change the status
+// of an object.
+func StatusChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !equality.Semantic.DeepEqual(status(e.ObjectOld), status(e.ObjectNew))
+		},
+	}
+}
+
+// status returns the status of an unstructured object, or nil for any other object.
+func status(object client.Object) interface{} {
+	if fields, ok := object.(*unstructured.Unstructured); ok {
+		return fields.Object["status"]
+	}
+
+	return nil
+}
+
+// isWatchedStatus determines if the reconciler is already watching the status of a particular kind.
+func isWatchedStatus(r workload.Reconciler, gvk schema.GroupVersionKind) bool {
+	for _, watched := range r.GetWatches() {
+		if watched.GetObjectKind().GroupVersionKind() == gvk && isStatusWatch(watched) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isStatusWatch determines if a watched object records a watch on the status of a kind.
+func isStatusWatch(watched client.Object) bool {
+	_, ok := watched.GetAnnotations()[statusWatchAnnotation]
+
+	return ok
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watches
+
+import (
+	"fmt"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// Children ensures that the reconciler is watching the kind of each of the child resources that
+// it renders.  Events on a child are mapped back to the parent workload via the controller owner
+// reference which is set on every child when it is persisted.  Children whose kind is not yet served
+// by the cluster (e.g. a custom resource whose CRD has not yet been created) are skipped and are
+// picked up on a subsequent reconciliation, once the API is available.
+func Children(r workload.Reconciler, req *workload.Request, children []client.Object) error {
+	for _, child := range children {
+		gvk := child.GetObjectKind().GroupVersionKind()
+
+		if isWatched(r, gvk) {
+			continue
+		}
+
+		served, err := isServed(r, gvk)
+		if err != nil {
+			return err
+		}
+
+		if !served {
+			req.Log.V(2).Info(
+				"skipping watch for child resource which is not yet served",
+				"group", gvk.Group,
+				"version", gvk.Version,
+				"kind", gvk.Kind,
+			)
+
+			continue
+		}
+
+		if err := r.GetController().Watch(
+			source.Kind(r.GetManager().GetCache(), child),
+			handler.EnqueueRequestForOwner(
+				r.GetManager().GetScheme(),
+				r.GetManager().GetRESTMapper(),
+				req.Workload,
+				handler.OnlyControllerOwner(),
+			),
+			ChildPredicates(),
+		); err != nil {
+			return fmt.Errorf("unable to watch child resource kind %s, %w", gvk, err)
+		}
+
+		r.SetWatch(child)
+	}
+
+	return nil
+}
+
+// ChildPredicates returns the filters which are used to filter child events prior to requeuing
+// the parent.  Creation events are ignored, as they are triggered by the parent itself, while
+// deletions and changes to the spec, labels or annotations of a child are always passed through.
+func ChildPredicates() predicate.Predicate {
+	return predicate.And(
+		predicate.Funcs{
+			CreateFunc: func(e event.CreateEvent) bool {
+				return false
+			},
+			GenericFunc: func(e event.GenericEvent) bool {
+				return false
+			},
+		},
+		predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.LabelChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+			predicate.Funcs{
+				// objects such as namespaces and rbac resources do not track a generation, so
+				// we must treat any update to them as a potential drift from the desired state.
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					return e.ObjectOld.GetGeneration() == 0 && e.ObjectNew.GetGeneration() == 0
+				},
+			},
+		),
+	)
+}
+
+// isWatched determines if the reconciler is already watching a particular kind.
+func isWatched(r workload.Reconciler, gvk schema.GroupVersionKind) bool {
+	for _, watched := range r.GetWatches() {
+		if watched.GetObjectKind().GroupVersionKind() == gvk && !isStatusWatch(watched) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isServed determines if a particular kind is currently served by the cluster.
+func isServed(r workload.Reconciler, gvk schema.GroupVersionKind) (bool, error) {
+	if _, err := r.GetManager().GetRESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+		if meta.IsNoMatchError(err) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("unable to determine if kind %s is served, %w", gvk, err)
+	}
+
+	return true, nil
+}
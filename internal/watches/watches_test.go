@@ -0,0 +1,231 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watches
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
+)
+
+var bundleGVK = schema.GroupVersionKind{Group: "trust.cert-manager.io", Version: "v1alpha1", Kind: "Bundle"}
+
+type fakeController struct {
+	controller.Controller
+
+	watches int
+}
+
+func (c *fakeController) Watch(_ source.Source, _ handler.EventHandler, _ ...predicate.Predicate) error {
+	c.watches++
+
+	return nil
+}
+
+type fakeManager struct {
+	manager.Manager
+
+	scheme *runtime.Scheme
+	mapper meta.RESTMapper
+}
+
+func (m *fakeManager) GetScheme() *runtime.Scheme     { return m.scheme }
+func (m *fakeManager) GetRESTMapper() meta.RESTMapper { return m.mapper }
+func (m *fakeManager) GetCache() cache.Cache          { return nil }
+
+type fakeReconciler struct {
+	workload.Reconciler
+
+	manager    *fakeManager
+	controller *fakeController
+	watched    []client.Object
+}
+
+func (r *fakeReconciler) GetManager() manager.Manager          { return r.manager }
+func (r *fakeReconciler) GetController() controller.Controller { return r.controller }
+func (r *fakeReconciler) GetWatches() []client.Object          { return r.watched }
+func (r *fakeReconciler) SetWatch(watch client.Object)         { r.watched = append(r.watched, watch) }
+
+func newFakeReconciler(t *testing.T) (*fakeReconciler, *meta.DefaultRESTMapper) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, deployv1beta1.AddToScheme(scheme))
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Namespace"), meta.RESTScopeRoot)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+
+	return &fakeReconciler{
+		manager:    &fakeManager{scheme: scheme, mapper: mapper},
+		controller: &fakeController{},
+	}, mapper
+}
+
+func bundle(name string) client.Object {
+	object := &unstructured.Unstructured{}
+	object.SetGroupVersionKind(bundleGVK)
+	object.SetName(name)
+
+	return object
+}
+
+func TestChildrenWatchesEachServedKindOnce(t *testing.T) {
+	t.Parallel()
+
+	r, mapper := newFakeReconciler(t)
+	req := &workload.Request{
+		Workload: &deployv1beta1.PlatformConfig{ObjectMeta: metav1.ObjectMeta{Name: "config"}},
+		Log:      logr.Discard(),
+	}
+
+	children := []client.Object{
+		&corev1.Namespace{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"}},
+		&corev1.ConfigMap{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"}, ObjectMeta: metav1.ObjectMeta{Name: "a"}},
+		&corev1.ConfigMap{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"}, ObjectMeta: metav1.ObjectMeta{Name: "b"}},
+		bundle("bundle"),
+	}
+
+	// the Bundle kind is not yet served, so it is skipped
+	require.NoError(t, Children(r, req, children))
+	require.Equal(t, 2, r.controller.watches)
+	require.Len(t, r.watched, 2)
+
+	// subsequent reconciliations do not watch the same kinds again
+	require.NoError(t, Children(r, req, children))
+	require.Equal(t, 2, r.controller.watches)
+
+	// the Bundle kind is watched once it is served
+	mapper.Add(bundleGVK, meta.RESTScopeRoot)
+
+	require.NoError(t, Children(r, req, children))
+	require.Equal(t, 3, r.controller.watches)
+	require.Len(t, r.watched, 3)
+}
+
+func TestChildPredicates(t *testing.T) {
+	t.Parallel()
+
+	predicates := ChildPredicates()
+
+	withGeneration := func(generation int64, labels map[string]string) client.Object {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "a", Generation: generation, Labels: labels}}
+	}
+
+	require.False(t, predicates.Create(event.CreateEvent{Object: withGeneration(1, nil)}))
+	require.False(t, predicates.Generic(event.GenericEvent{Object: withGeneration(1, nil)}))
+	require.True(t, predicates.Delete(event.DeleteEvent{Object: withGeneration(1, nil)}))
+
+	for name, tc := range map[string]struct {
+		old, new client.Object
+		expected bool
+	}{
+		"generation changed":   {old: withGeneration(1, nil), new: withGeneration(2, nil), expected: true},
+		"labels changed":       {old: withGeneration(1, nil), new: withGeneration(1, map[string]string{"a": "b"}), expected: true},
+		"status only":          {old: withGeneration(1, nil), new: withGeneration(1, nil), expected: false},
+		"untracked generation": {old: withGeneration(0, nil), new: withGeneration(0, nil), expected: true},
+	} {
+		require.Equal(t, tc.expected, predicates.Update(event.UpdateEvent{ObjectOld: tc.old, ObjectNew: tc.new}), name)
+	}
+}
+
+func TestCapabilitiesWatchesStatusOnce(t *testing.T) {
+	t.Parallel()
+
+	r, mapper := newFakeReconciler(t)
+	req := &workload.Request{
+		Workload: &deployv1beta1.PlatformOperators{ObjectMeta: metav1.ObjectMeta{Name: "operators"}},
+		Log:      logr.Discard(),
+	}
+
+	list := func() client.ObjectList { return &deployv1beta1.PlatformOperatorsList{} }
+
+	// the Bundle kind is not yet served, so it is skipped
+	require.NoError(t, Capabilities(r, req, list, bundleGVK))
+	require.Equal(t, 0, r.controller.watches)
+
+	mapper.Add(bundleGVK, meta.RESTScopeRoot)
+
+	require.NoError(t, Capabilities(r, req, list, bundleGVK))
+	require.NoError(t, Capabilities(r, req, list, bundleGVK))
+	require.Equal(t, 1, r.controller.watches)
+
+	// the watch on the status does not stand in for the watch on children of the same kind
+	require.NoError(t, Children(r, req, []client.Object{bundle("bundle")}))
+	require.Equal(t, 2, r.controller.watches)
+}
+
+func TestStatusChangedPredicate(t *testing.T) {
+	t.Parallel()
+
+	predicates := StatusChangedPredicate()
+
+	withStatus := func(created bool) client.Object {
+		object := bundle("bundle").(*unstructured.Unstructured)
+		object.Object["status"] = map[string]interface{}{"created": created}
+
+		return object
+	}
+
+	require.False(t, predicates.Create(event.CreateEvent{Object: withStatus(true)}))
+	require.False(t, predicates.Delete(event.DeleteEvent{Object: withStatus(true)}))
+	require.False(t, predicates.Update(event.UpdateEvent{ObjectOld: withStatus(true), ObjectNew: withStatus(true)}))
+	require.True(t, predicates.Update(event.UpdateEvent{ObjectOld: withStatus(false), ObjectNew: withStatus(true)}))
+}
+
+func TestCertificatesWatchesOnceServed(t *testing.T) {
+	t.Parallel()
+
+	r, mapper := newFakeReconciler(t)
+	req := &workload.Request{
+		Workload: &deployv1beta1.PlatformConfig{ObjectMeta: metav1.ObjectMeta{Name: "config"}},
+		Log:      logr.Discard(),
+	}
+
+	list := func() client.ObjectList { return &deployv1beta1.PlatformConfigList{} }
+	selected := func(client.Object) bool { return true }
+
+	// cert-manager is not yet installed, so the Certificate kind is skipped
+	require.NoError(t, Certificates(r, req, list, selected))
+	require.Equal(t, 0, r.controller.watches)
+
+	mapper.Add(certificateGVK, meta.RESTScopeNamespace)
+
+	require.NoError(t, Certificates(r, req, list, selected))
+	require.NoError(t, Certificates(r, req, list, selected))
+	require.Equal(t, 1, r.controller.watches)
+}
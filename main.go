@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
 	"os"
@@ -25,17 +26,24 @@ import (
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	"github.com/go-logr/logr"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	deployv1alpha1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1alpha1"
+	deployv1beta1 "github.com/tbd-paas/platform-config-operator/apis/deploy/v1beta1"
 	deploycontrollers "github.com/tbd-paas/platform-config-operator/controllers/deploy"
+	"github.com/tbd-paas/platform-config-operator/internal/certs"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -51,27 +59,38 @@ var (
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
 
 	utilruntime.Must(deployv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(deployv1beta1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
 func main() {
-	var metricsAddr string
+	var metrics metricsConfig
 	var enableLeaderElection bool
 	var probeAddr string
-	var secureMetrics bool
 	var enableHTTP2 bool
+	var enableWebhooks bool
+	var webhookCertDir string
+	var webhookServiceName string
+	var webhookSecretName string
 
-	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
+	metrics.bindFlags(flag.CommandLine)
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
-	flag.BoolVar(&secureMetrics, "metrics-secure", false,
-		"If set the metrics endpoint is served securely")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", true,
+		"If set, the conversion webhook will be served.  Disable when running outside of the cluster.")
+	flag.StringVar(&webhookCertDir, "webhook-cert-dir", "/tmp/k8s-webhook-server/serving-certs",
+		"The directory that the webhook server reads its serving certificate from.")
+	flag.StringVar(&webhookServiceName, "webhook-service-name", "platform-config-operator-webhook-service",
+		"The name of the service which fronts the webhook server.")
+	flag.StringVar(&webhookSecretName, "webhook-secret-name", "platform-config-operator-webhook-server-cert",
+		"The name of the secret which stores the serving certificate of the webhook server.")
 
 	opts := zap.Options{
 		Development: true,
@@ -104,16 +123,57 @@ func main() {
 		tlsOpts = append(tlsOpts, disableHTTP2)
 	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	config := ctrl.GetConfigOrDie()
+
+	// the serving certificate must exist before the webhook server starts, so it is ensured
+	// with a client which does not depend upon the cache of the manager, and is then renewed by
+	// the manager as it runs.
+	var webhookCertRenewer *certs.Renewer
+
+	if enableWebhooks {
+		certClient, err := client.New(config, client.Options{Scheme: scheme})
+		if err != nil {
+			setupLog.Error(err, "unable to create client for webhook certificate")
+			os.Exit(1)
+		}
+
+		certOpts := certs.Options{
+			Namespace:   os.Getenv("POD_NAMESPACE"),
+			ServiceName: webhookServiceName,
+			SecretName:  webhookSecretName,
+			CertDir:     webhookCertDir,
+			CRDNames: []string{
+				"platformconfigs.deploy.platform.tbd.io",
+				"platformoperators.deploy.platform.tbd.io",
+			},
+		}
+
+		if err := certs.Ensure(context.Background(), certClient, certOpts); err != nil {
+			setupLog.Error(err, "unable to ensure webhook certificate")
+			os.Exit(1)
+		}
+
+		webhookCertRenewer = certs.NewRenewer(certClient, certOpts, ctrl.Log.WithName("webhook-certificate"))
+	}
+
+	// the certificate of the metrics server is issued by cert-manager once the certificates capability
+	// is ready, and is reloaded as it is renewed.
+	metricsOpts, metricsCertReloader, err := metrics.serverOptions(tlsOpts, ctrl.Log.WithName("metrics-certificate"))
+	if err != nil {
+		setupLog.Error(err, "unable to load metrics certificate")
+		os.Exit(1)
+	}
+
+	mgr, err := ctrl.NewManager(config, ctrl.Options{
 		Scheme:                 scheme,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "f65ae266.platform.tbd.io",
-		Metrics: metricsserver.Options{
-			BindAddress:   metricsAddr,
-			SecureServing: secureMetrics,
-			TLSOpts:       tlsOpts,
-		},
+		Metrics:                metricsOpts,
+		WebhookServer: webhook.NewServer(webhook.Options{
+			CertDir: webhookCertDir,
+			TLSOpts: tlsOpts,
+		}),
 	})
 
 	if err != nil {
@@ -121,6 +181,20 @@ func main() {
 		os.Exit(1)
 	}
 
+	if webhookCertRenewer != nil {
+		if err := mgr.Add(webhookCertRenewer); err != nil {
+			setupLog.Error(err, "unable to set up webhook certificate renewer")
+			os.Exit(1)
+		}
+	}
+
+	if metricsCertReloader != nil {
+		if err := mgr.Add(metricsCertReloader); err != nil {
+			setupLog.Error(err, "unable to set up metrics certificate reloader")
+			os.Exit(1)
+		}
+	}
+
 	reconcilers := []ReconcilerInitializer{
 		deploycontrollers.NewPlatformOperatorsReconciler(mgr),
 		deploycontrollers.NewPlatformConfigReconciler(mgr),
@@ -134,6 +208,19 @@ func main() {
 		}
 	}
 
+	if enableWebhooks {
+		if err = (&deployv1beta1.PlatformConfig{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "PlatformConfig")
+			os.Exit(1)
+		}
+
+		if err = (&deployv1beta1.PlatformOperators{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "PlatformOperators")
+			os.Exit(1)
+		}
+	}
+	// +kubebuilder:scaffold:webhook
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -151,3 +238,53 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// metricsConfig is the configuration of the metrics server, which is bound to the command line flags.
+type metricsConfig struct {
+	bindAddress string
+	secure      bool
+	certPath    string
+}
+
+// bindFlags binds the flags which configure the metrics server.
+func (c *metricsConfig) bindFlags(flags *flag.FlagSet) {
+	flags.StringVar(&c.bindAddress, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
+	flags.BoolVar(&c.secure, "metrics-secure", false,
+		"If set the metrics endpoint is served securely, and requests are authenticated and authorized "+
+			"against the Kubernetes API")
+	flags.StringVar(&c.certPath, "metrics-cert-path", "",
+		"The directory that the metrics server reads its serving certificate from, which is reloaded as it "+
+			"is renewed.  A self-signed certificate is served until the certificate is mounted.")
+}
+
+// serverOptions returns the options of the metrics server, along with the Reloader of its certificate
+// when the certificate is read from a directory, which must be added to the manager.
+func (c *metricsConfig) serverOptions(
+	tlsOpts []func(*tls.Config),
+	log logr.Logger,
+) (metricsserver.Options, *certs.Reloader, error) {
+	opts := metricsserver.Options{
+		BindAddress:   c.bindAddress,
+		SecureServing: c.secure,
+		TLSOpts:       tlsOpts,
+	}
+
+	if !c.secure {
+		return opts, nil, nil
+	}
+
+	opts.FilterProvider = filters.WithAuthenticationAndAuthorization
+
+	if c.certPath == "" {
+		return opts, nil, nil
+	}
+
+	reloader, err := certs.NewReloader(c.certPath, log)
+	if err != nil {
+		return metricsserver.Options{}, nil, err
+	}
+
+	opts.TLSOpts = append(append([]func(*tls.Config){}, tlsOpts...), reloader.TLSOpt())
+
+	return opts, reloader, nil
+}
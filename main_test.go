@@ -0,0 +1,145 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	certutil "k8s.io/client-go/util/cert"
+	"sigs.k8s.io/yaml"
+)
+
+// TestMetricsFlags ensures that the flags which the manager is deployed with configure the
+// metrics server.
+func TestMetricsFlags(t *testing.T) {
+	t.Parallel()
+
+	patch, err := os.ReadFile(filepath.Join("config", "default", "manager_secure_metrics_patch.yaml"))
+	require.NoError(t, err)
+
+	var deployment struct {
+		Spec struct {
+			Template struct {
+				Spec struct {
+					Containers []struct {
+						Name string   `json:"name"`
+						Args []string `json:"args"`
+					} `json:"containers"`
+				} `json:"spec"`
+			} `json:"template"`
+		} `json:"spec"`
+	}
+
+	require.NoError(t, yaml.Unmarshal(patch, &deployment))
+	require.NotEmpty(t, deployment.Spec.Template.Spec.Containers)
+
+	flags := flag.NewFlagSet("manager", flag.ContinueOnError)
+	flags.String("health-probe-bind-address", "", "")
+	flags.Bool("leader-elect", false, "")
+
+	var metrics metricsConfig
+	metrics.bindFlags(flags)
+
+	require.NoError(t, flags.Parse(deployment.Spec.Template.Spec.Containers[0].Args))
+	require.Equal(t, metricsConfig{bindAddress: ":8443", secure: true, certPath: "/etc/metrics-certs"}, metrics)
+}
+
+func TestMetricsServerOptions(t *testing.T) {
+	t.Parallel()
+
+	// a certificate which is mounted before the manager starts is served immediately
+	mounted := t.TempDir()
+
+	certPEM, keyPEM, err := certutil.GenerateSelfSignedCertKey("metrics.tbd-operators-system.svc", nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(mounted, corev1.TLSCertKey), certPEM, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(mounted, corev1.TLSPrivateKeyKey), keyPEM, 0o600))
+
+	for _, tt := range []struct {
+		name   string
+		config metricsConfig
+
+		// reloaded determines if the certificate is served by a reloader, and dnsName is the name of
+		// the certificate it serves
+		reloaded bool
+		dnsName  string
+	}{
+		{name: "insecure", config: metricsConfig{bindAddress: ":8080"}},
+		{name: "secure", config: metricsConfig{bindAddress: ":8443", secure: true}},
+		{
+			name:     "certificate not yet mounted",
+			config:   metricsConfig{bindAddress: ":8443", secure: true, certPath: t.TempDir()},
+			reloaded: true,
+			dnsName:  "localhost",
+		},
+		{
+			name:     "certificate mounted",
+			config:   metricsConfig{bindAddress: ":8443", secure: true, certPath: mounted},
+			reloaded: true,
+			dnsName:  "metrics.tbd-operators-system.svc",
+		},
+		{
+			name:   "certificate path without secure serving",
+			config: metricsConfig{bindAddress: ":8080", certPath: mounted},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			disableHTTP2 := func(c *tls.Config) { c.NextProtos = []string{"http/1.1"} }
+
+			opts, reloader, err := tt.config.serverOptions([]func(*tls.Config){disableHTTP2}, logr.Discard())
+			require.NoError(t, err)
+			require.Equal(t, tt.config.bindAddress, opts.BindAddress)
+			require.Equal(t, tt.config.secure, opts.SecureServing)
+			require.Equal(t, tt.config.secure, opts.FilterProvider != nil)
+
+			config := &tls.Config{}
+			for _, opt := range opts.TLSOpts {
+				opt(config)
+			}
+
+			// the options of the other servers are kept
+			require.Equal(t, []string{"http/1.1"}, config.NextProtos)
+
+			if !tt.reloaded {
+				require.Nil(t, reloader)
+				require.Nil(t, config.GetCertificate)
+
+				return
+			}
+
+			require.NotNil(t, reloader)
+			require.NotNil(t, config.GetCertificate)
+
+			served, err := config.GetCertificate(&tls.ClientHelloInfo{})
+			require.NoError(t, err)
+
+			leaf, err := x509.ParseCertificate(served.Certificate[0])
+			require.NoError(t, err)
+			require.NoError(t, leaf.VerifyHostname(tt.dnsName))
+		})
+	}
+}
@@ -34,10 +34,6 @@ import (
 // deployv1alpha1PlatformConfig tests
 func deployv1alpha1PlatformConfigChildrenFuncs(tester *E2ETest) error {
 	// TODO: need to run r.GetResources(request) on the reconciler to get the mutated resources
-	if len(platformconfig.CreateFuncs) == 0 {
-		return nil
-	}
-
 	workload, err := platformconfig.ConvertWorkload(tester.workload)
 	if err != nil {
 		return fmt.Errorf("error in workload conversion; %w", err)